@@ -0,0 +1,214 @@
+// Package client is a Go client of the ptask HTTP API.
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/KarolosLykos/ptask/internal/constants"
+	"github.com/KarolosLykos/ptask/internal/utils/response"
+)
+
+const (
+	defaultRetries = 2
+	defaultBackoff = 100 * time.Millisecond
+)
+
+// Client calls the ptask API.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	retries    int
+	backoff    time.Duration
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client requests are sent with.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithRetries sets how many times a request failing with a network error, a 429 or a 5xx is retried,
+// waiting backoff, doubled on every attempt, in between.
+func WithRetries(retries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.retries = retries
+		c.backoff = backoff
+	}
+}
+
+// New returns a client of the API served at baseURL, e.g. http://localhost:8080.
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: http.DefaultClient,
+		retries:    defaultRetries,
+		backoff:    defaultBackoff,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// ListRequest selects the occurrences of a periodic task in [T1, T2).
+type ListRequest struct {
+	Period       string
+	TZ           string
+	T1           time.Time
+	T2           time.Time
+	BlackoutSets []string
+}
+
+func (r *ListRequest) query() url.Values {
+	q := url.Values{}
+	q.Set("period", r.Period)
+	q.Set("tz", r.TZ)
+	q.Set("t1", r.T1.UTC().Format(constants.TimestampLayout))
+	q.Set("t2", r.T2.UTC().Format(constants.TimestampLayout))
+
+	for _, set := range r.BlackoutSets {
+		q.Add("blackout_set", set)
+	}
+
+	return q
+}
+
+// List returns the occurrences of a periodic task.
+func (c *Client) List(ctx context.Context, req *ListRequest) ([]time.Time, error) {
+	var list []string
+	if err := c.get(ctx, "/v1/ptlist", req.query(), &list); err != nil {
+		return nil, err
+	}
+
+	occurrences := make([]time.Time, 0, len(list))
+
+	for _, timestamp := range list {
+		t, err := time.Parse(constants.TimestampLayout, timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("ptask: could not parse timestamp %q: %w", timestamp, err)
+		}
+
+		occurrences = append(occurrences, t)
+	}
+
+	return occurrences, nil
+}
+
+// Description is the human-readable description of a period in a timezone.
+type Description struct {
+	Period      string `json:"period"`
+	Timezone    string `json:"tz"`
+	Lang        string `json:"lang"`
+	Description string `json:"description"`
+}
+
+// Describe returns the human-readable description of a period in a timezone and language.
+func (c *Client) Describe(ctx context.Context, period, tz, lang string) (*Description, error) {
+	q := url.Values{}
+	q.Set("period", period)
+	q.Set("tz", tz)
+	q.Set("lang", lang)
+
+	description := &Description{}
+	if err := c.get(ctx, "/v1/describe", q, description); err != nil {
+		return nil, err
+	}
+
+	return description, nil
+}
+
+func (c *Client) get(ctx context.Context, path string, q url.Values, data interface{}) error {
+	var err error
+
+	backoff := c.backoff
+
+	for attempt := 0; ; attempt++ {
+		var retry bool
+
+		retry, err = c.do(ctx, path, q, data)
+		if err == nil || !retry || attempt >= c.retries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+			backoff *= 2
+		}
+	}
+}
+
+// do sends a single request and reports whether it is worth retrying when it fails.
+func (c *Client) do(ctx context.Context, path string, q url.Values, data interface{}) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path+"?"+q.Encode(), nil)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return true, err
+	}
+
+	resp := &response.Response{}
+	if err = json.Unmarshal(body, resp); err != nil {
+		return isRetryable(res.StatusCode), &APIError{StatusCode: res.StatusCode, Message: strings.TrimSpace(string(body))}
+	}
+
+	if res.StatusCode != http.StatusOK || resp.Status != constants.StatusSuccess {
+		apiErr := &APIError{StatusCode: res.StatusCode, Code: resp.Code, Message: resp.Error}
+
+		if resp.Data != nil {
+			apiErr.Details, _ = json.Marshal(resp.Data)
+		}
+
+		return isRetryable(res.StatusCode), apiErr
+	}
+
+	// decode the data field again into its concrete type.
+	raw, err := json.Marshal(resp.Data)
+	if err != nil {
+		return false, err
+	}
+
+	if err = json.Unmarshal(raw, data); err != nil {
+		return false, fmt.Errorf("ptask: could not decode response: %w", err)
+	}
+
+	return false, nil
+}
+
+func isRetryable(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
+
+// IsRetryable reports whether err is a transient failure of the API.
+func IsRetryable(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return isRetryable(apiErr.StatusCode)
+	}
+
+	return false
+}
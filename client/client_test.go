@@ -0,0 +1,258 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/ptask/internal/api"
+	"github.com/KarolosLykos/ptask/internal/logger"
+	"github.com/KarolosLykos/ptask/internal/logger/log"
+	"github.com/KarolosLykos/ptask/internal/ptask/usecase"
+	"github.com/KarolosLykos/ptask/internal/utils/httperrors"
+	"github.com/KarolosLykos/ptask/internal/utils/response"
+)
+
+func TestClient_List(t *testing.T) {
+	srv := newServer(t)
+
+	c := New(srv.URL)
+
+	tt := []struct {
+		name  string
+		req   *ListRequest
+		err   error
+		count int
+	}{
+		{name: "daily", req: listRequest("1d", "2021-07-28T20:46:03Z", "2021-08-02T12:34:56Z"), count: 5},
+		{name: "invalid period", req: listRequest("1w", "2021-07-28T20:46:03Z", "2021-08-02T12:34:56Z"), err: ErrInvalidPeriod},
+		{name: "invalid timezone", req: &ListRequest{Period: "1d", TZ: "Asia/Nowhere", T1: parse("2021-07-28T20:46:03Z"), T2: parse("2021-08-02T12:34:56Z")}, err: ErrInvalidTimezone},
+		{name: "unknown blackout set", req: &ListRequest{Period: "1d", TZ: "Europe/Athens", T1: parse("2021-07-28T20:46:03Z"), T2: parse("2021-08-02T12:34:56Z"), BlackoutSets: []string{"holidays"}}, err: ErrUnknownBlackout},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			list, err := c.List(context.Background(), tc.req)
+			if tc.err != nil {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tc.err)
+
+				var apiErr *APIError
+				require.ErrorAs(t, err, &apiErr)
+				assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+				assert.False(t, IsRetryable(err))
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, list, tc.count)
+		})
+	}
+}
+
+func TestClient_Describe(t *testing.T) {
+	c := New(newServer(t).URL)
+
+	description, err := c.Describe(context.Background(), "1mo", "Europe/Athens", "en")
+	require.NoError(t, err)
+	assert.Equal(t, "Every month on the 1st at 00:00 (Europe/Athens)", description.Description)
+
+	_, err = c.Describe(context.Background(), "1mo", "Europe/Athens", "fr")
+	assert.ErrorIs(t, err, ErrUnsupportedLanguage)
+}
+
+func TestClient_ErrorCodes(t *testing.T) {
+	// a code the client has no sentinel for still matches the error of the service with errors.Is.
+	errQuota := httperrors.New("quota_exceeded", http.StatusBadRequest, "quota exceeded")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response.Error(w, httperrors.Wrapf(errQuota, "%d requests left", 0))
+	}))
+	t.Cleanup(srv.Close)
+
+	_, err := New(srv.URL).List(context.Background(), listRequest("1h", "2021-07-14T20:46:03Z", "2021-07-15T12:34:56Z"))
+	assert.ErrorIs(t, err, errQuota)
+	assert.NotErrorIs(t, err, ErrInvalidPeriod)
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "quota_exceeded", apiErr.Code)
+	assert.Equal(t, "quota exceeded", apiErr.Message)
+}
+
+func TestClient_Retries(t *testing.T) {
+	handler := api.New(getLogger(), ":0", usecase.NewPeriodicTaskUC(getLogger())).Handler()
+
+	tt := []struct {
+		name     string
+		failures int32
+		status   int
+		retries  int
+		calls    int32
+		err      bool
+	}{
+		{name: "recovers from 5xx", failures: 2, status: http.StatusServiceUnavailable, retries: 2, calls: 3},
+		{name: "recovers from 429", failures: 1, status: http.StatusTooManyRequests, retries: 2, calls: 2},
+		{name: "gives up", failures: 5, status: http.StatusBadGateway, retries: 2, calls: 3, err: true},
+		{name: "no retries", failures: 1, status: http.StatusInternalServerError, retries: 0, calls: 1, err: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls int32
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&calls, 1) <= tc.failures {
+					w.WriteHeader(tc.status)
+					return
+				}
+
+				handler.ServeHTTP(w, r)
+			}))
+			t.Cleanup(srv.Close)
+
+			c := New(srv.URL, WithRetries(tc.retries, time.Millisecond))
+
+			list, err := c.List(context.Background(), listRequest("1h", "2021-07-14T20:46:03Z", "2021-07-15T12:34:56Z"))
+			assert.Equal(t, tc.calls, atomic.LoadInt32(&calls))
+
+			if tc.err {
+				require.Error(t, err)
+				assert.True(t, IsRetryable(err))
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotEmpty(t, list)
+		})
+	}
+}
+
+func TestClient_RetriesRespectContext(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	c := New(srv.URL, WithRetries(100, time.Second))
+
+	_, err := c.List(ctx, listRequest("1h", "2021-07-14T20:46:03Z", "2021-07-15T12:34:56Z"))
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestClient_ListPages(t *testing.T) {
+	c := New(newServer(t).URL)
+
+	tt := []struct {
+		name   string
+		req    *ListRequest
+		window time.Duration
+	}{
+		{name: "hourly in daily pages", req: listRequest("1h", "2021-07-14T20:46:03Z", "2021-07-18T12:34:56Z"), window: 24 * time.Hour},
+		{name: "every 5 hours in small pages", req: listRequest("5h", "2021-07-14T20:46:03Z", "2021-07-18T12:34:56Z"), window: 3 * time.Hour},
+		{name: "every 3 days in daily pages", req: listRequest("3d", "2021-07-14T20:46:03Z", "2021-09-18T12:34:56Z"), window: 24 * time.Hour},
+		{name: "monthly in weekly pages", req: listRequest("1mo", "2021-02-14T20:46:03Z", "2022-09-18T12:34:56Z"), window: 7 * 24 * time.Hour},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			want, err := c.List(context.Background(), tc.req)
+			require.NoError(t, err)
+
+			var got []time.Time
+
+			err = c.ListPages(context.Background(), tc.req, tc.window, func(page []time.Time) error {
+				assert.NotEmpty(t, page)
+				got = append(got, page...)
+
+				return nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+
+			var streamed []time.Time
+
+			it := c.Occurrences(context.Background(), tc.req, tc.window)
+			for it.Next() {
+				streamed = append(streamed, it.Time())
+			}
+
+			require.NoError(t, it.Err())
+			assert.Equal(t, want, streamed)
+		})
+	}
+}
+
+func TestClient_ListPagesErrors(t *testing.T) {
+	c := New(newServer(t).URL)
+
+	err := c.ListPages(context.Background(), listRequest("1h", "2021-07-14T20:46:03Z", "2021-07-18T12:34:56Z"), 0, nil)
+	assert.ErrorIs(t, err, ErrInvalidWindow)
+
+	stop := errors.New("stop")
+	pages := 0
+
+	err = c.ListPages(context.Background(), listRequest("1h", "2021-07-14T20:46:03Z", "2021-07-18T12:34:56Z"), time.Hour*24, func(page []time.Time) error {
+		pages++
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, pages)
+
+	it := c.Occurrences(context.Background(), listRequest("wrong", "2021-07-14T20:46:03Z", "2021-07-18T12:34:56Z"), time.Hour)
+	assert.False(t, it.Next())
+	assert.ErrorIs(t, it.Err(), ErrInvalidPeriod)
+
+	it = c.Occurrences(context.Background(), listRequest("1h", "2021-07-14T20:46:03Z", "2021-07-18T12:34:56Z"), time.Hour)
+	require.True(t, it.Next())
+	it.Close()
+	assert.NoError(t, it.Err())
+}
+
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	l := getLogger()
+
+	srv := httptest.NewServer(api.New(l, ":0", usecase.NewPeriodicTaskUC(l)).Handler())
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func listRequest(period, t1, t2 string) *ListRequest {
+	return &ListRequest{Period: period, TZ: "Europe/Athens", T1: parse(t1), T2: parse(t2)}
+}
+
+func parse(s string) time.Time {
+	t, _ := time.Parse(time.RFC3339, s)
+	return t
+}
+
+func getLogger() logger.Logger {
+	l := &logrus.Logger{
+		Out:          io.Discard,
+		Hooks:        make(logrus.LevelHooks),
+		ReportCaller: false,
+		ExitFunc:     os.Exit,
+		Level:        logrus.DebugLevel,
+		Formatter:    &logrus.JSONFormatter{},
+	}
+
+	return log.New(l)
+}
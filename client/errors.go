@@ -0,0 +1,51 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/KarolosLykos/ptask/internal/utils/httperrors"
+)
+
+// The errors the API reports. They are the very values the service returns, so errors.Is matches them
+// against an *APIError by the code of its response.
+var (
+	ErrInternalServer      = httperrors.ErrInternalServer
+	ErrRecoverPanic        = httperrors.ErrRecoverPanic
+	ErrInvalidPeriod       = httperrors.ErrInvalidPeriod
+	ErrInvalidTimezone     = httperrors.ErrInvalidTimezone
+	ErrInvalidStartPoint   = httperrors.ErrInvalidStartPoint
+	ErrInvalidEndPoint     = httperrors.ErrInvalidEndPoint
+	ErrInvalidBlackout     = httperrors.ErrInvalidBlackout
+	ErrUnknownBlackout     = httperrors.ErrUnknownBlackout
+	ErrInvalidExpression   = httperrors.ErrInvalidExpression
+	ErrInvalidSchedules    = httperrors.ErrInvalidSchedules
+	ErrUnsupportedLanguage = httperrors.ErrUnsupportedLanguage
+	ErrUnparsableSchedule  = httperrors.ErrUnparsableSchedule
+	ErrInvalidCount        = httperrors.ErrInvalidCount
+	ErrInvalidTimestamp    = httperrors.ErrInvalidTimestamp
+	ErrNotReady            = httperrors.ErrNotReady
+	ErrUnauthorized        = httperrors.ErrUnauthorized
+)
+
+// APIError is an error response of the API. Code identifies its kind, e.g. "invalid_period".
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	Details    json.RawMessage
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("ptask: %d: %s", e.StatusCode, e.Message)
+}
+
+// Unwrap returns the error of the service the code of the response identifies, if any. It matches the
+// sentinel of the same code with errors.Is, including the ones of codes this client does not know of.
+func (e *APIError) Unwrap() error {
+	if e.Code == "" {
+		return nil
+	}
+
+	return httperrors.New(e.Code, e.StatusCode, e.Message)
+}
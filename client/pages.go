@@ -0,0 +1,160 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrInvalidWindow is returned when paging with a window that is not positive.
+var ErrInvalidWindow = errors.New("ptask: window must be positive")
+
+// errClosed stops the paging of a closed iterator.
+var errClosed = errors.New("ptask: iterator closed")
+
+// ListPages lists the occurrences of req a window at a time and calls fn with every non-empty page.
+// Every page restarts at the last occurrence seen, so periods with a value greater than one keep their
+// alignment across pages. It stops at the first error, including the one fn returns.
+func (c *Client) ListPages(
+	ctx context.Context,
+	req *ListRequest,
+	window time.Duration,
+	fn func(page []time.Time) error,
+) error {
+	if window <= 0 {
+		return ErrInvalidWindow
+	}
+
+	var last time.Time
+
+	start := req.T1
+	end := start.Add(window)
+
+	for start.Before(req.T2) {
+		if end.After(req.T2) {
+			end = req.T2
+		}
+
+		pageReq := *req
+		pageReq.T1, pageReq.T2 = start, end
+
+		list, err := c.List(ctx, &pageReq)
+		if err != nil {
+			return err
+		}
+
+		page := make([]time.Time, 0, len(list))
+
+		for _, t := range list {
+			if last.IsZero() || t.After(last) {
+				page = append(page, t)
+			}
+		}
+
+		if len(page) == 0 {
+			// nothing new in the window, widen it without moving its start.
+			if !end.Before(req.T2) {
+				return nil
+			}
+
+			end = end.Add(window)
+
+			continue
+		}
+
+		if err = fn(page); err != nil {
+			return err
+		}
+
+		last = page[len(page)-1]
+		start = last.Add(-time.Second)
+		end = start.Add(window)
+	}
+
+	return nil
+}
+
+// Occurrences streams the occurrences of a periodic task, fetching them a window at a time.
+//
+//	it := c.Occurrences(ctx, req, 24*time.Hour)
+//	for it.Next() {
+//		fmt.Println(it.Time())
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Occurrences struct {
+	next   chan time.Time
+	done   chan struct{}
+	err    error
+	cancel context.CancelFunc
+	cur    time.Time
+}
+
+// Occurrences returns an iterator over the occurrences of req, see ListPages.
+func (c *Client) Occurrences(ctx context.Context, req *ListRequest, window time.Duration) *Occurrences {
+	ctx, cancel := context.WithCancel(ctx)
+
+	it := &Occurrences{next: make(chan time.Time), done: make(chan struct{}), cancel: cancel}
+
+	go func() {
+		defer close(it.next)
+
+		err := c.ListPages(ctx, req, window, func(page []time.Time) error {
+			for _, t := range page {
+				select {
+				case it.next <- t:
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-it.done:
+					return errClosed
+				}
+			}
+
+			return nil
+		})
+
+		if !errors.Is(err, errClosed) {
+			it.err = err
+		}
+	}()
+
+	return it
+}
+
+// Next advances to the next occurrence and reports whether there is one.
+func (it *Occurrences) Next() bool {
+	t, ok := <-it.next
+	if !ok {
+		it.cancel()
+		return false
+	}
+
+	it.cur = t
+
+	return true
+}
+
+// Time returns the current occurrence.
+func (it *Occurrences) Time() time.Time {
+	return it.cur
+}
+
+// Err returns the error that stopped the iteration, if any. It is valid once Next returns false.
+func (it *Occurrences) Err() error {
+	return it.err
+}
+
+// Close stops the iteration early.
+func (it *Occurrences) Close() {
+	select {
+	case <-it.done:
+	default:
+		close(it.done)
+	}
+
+	for range it.next { //nolint:revive // drain until the producer stops.
+	}
+
+	it.cancel()
+}
@@ -0,0 +1,231 @@
+// Code generated by swaggo/swag. DO NOT EDIT
+package docs
+
+import "github.com/swaggo/swag"
+
+const docTemplatev2 = `{
+    "schemes": {{ marshal .Schemes }},
+    "swagger": "2.0",
+    "info": {
+        "description": "{{escape .Description}}",
+        "title": "{{.Title}}",
+        "contact": {
+            "name": "API Support",
+            "url": "http://www.swagger.io/support",
+            "email": "support@swagger.io"
+        },
+        "license": {
+            "name": "Apache 2.0",
+            "url": "http://www.apache.org/licenses/LICENSE-2.0.html"
+        },
+        "version": "{{.Version}}"
+    },
+    "host": "{{.Host}}",
+    "basePath": "{{.BasePath}}",
+    "paths": {
+        "/healthz": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1",
+                    "v2"
+                ],
+                "summary": "Returns 200 as long as the service is alive.",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1",
+                    "v2"
+                ],
+                "summary": "Returns 200 when the service and its dependencies are ready, 503 once it drains or while a dependency fails.",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/v2/ptlist": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Returns all matching occurrences of a periodic task between 2 points in time, in RFC 3339 and rendered in the task and display timezones.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "1y,1mo,1d,1h",
+                        "description": "Period",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "America/Los_Angeles",
+                        "description": "Timezone",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "20060102T150405Z",
+                        "description": "Start point",
+                        "name": "t1",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "20060102T150405Z",
+                        "description": "End point",
+                        "name": "t2",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Blackout interval (t1/t2)",
+                        "name": "blackout",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Named blackout set",
+                        "name": "blackout_set",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Report suppressed occurrences",
+                        "name": "show_suppressed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Display timezone",
+                        "name": "display_tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "en,el,de",
+                        "description": "Language of the description",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/domain.OccurrenceList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
+        "/version": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v1",
+                    "v2"
+                ],
+                "summary": "Returns the git commit and time of the build, the Go version and the version of the tz database the service runs with.",
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.Occurrence": {
+            "type": "object",
+            "properties": {
+                "local": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "suppressed": {
+                    "type": "boolean"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "domain.OccurrenceList": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "occurrences": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Occurrence"
+                    }
+                }
+            }
+        }
+    }
+}`
+
+// SwaggerInfov2 holds exported Swagger Info so clients can modify it
+var SwaggerInfov2 = &swag.Spec{
+	Version:          "1.0",
+	Host:             "",
+	BasePath:         "",
+	Schemes:          []string{},
+	Title:            "Periodic Task Api",
+	Description:      "JSON/HTTP service in Golang, that returns the matching timestamps of a periodic task.",
+	InfoInstanceName: "v2",
+	SwaggerTemplate:  docTemplatev2,
+}
+
+func init() {
+	swag.Register(SwaggerInfov2.InstanceName(), SwaggerInfov2)
+}
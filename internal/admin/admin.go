@@ -0,0 +1,144 @@
+// Package admin serves the operational endpoints of the service on a listener of their own, behind a bearer
+// token: the log level, the running config, goroutine and heap profiles and the requests in flight.
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"net/http/pprof"
+	"strings"
+	"sync/atomic"
+
+	"github.com/gorilla/mux"
+
+	"github.com/KarolosLykos/ptask/internal/api"
+	"github.com/KarolosLykos/ptask/internal/api/middlewares"
+	"github.com/KarolosLykos/ptask/internal/config"
+	"github.com/KarolosLykos/ptask/internal/httpserver"
+	"github.com/KarolosLykos/ptask/internal/logger"
+	"github.com/KarolosLykos/ptask/internal/utils/httperrors"
+	"github.com/KarolosLykos/ptask/internal/utils/response"
+)
+
+type Server struct {
+	logger  logger.Logger
+	api     *api.API
+	levels  *levels
+	handler http.Handler
+	server  *httpserver.Server
+
+	// replaced on reload.
+	token  atomic.Pointer[string]
+	config atomic.Pointer[config.Config]
+}
+
+// New returns the admin server of a, listening on addr once started with the timeouts of a. Every request
+// must carry token as a bearer token.
+func New(logger logger.Logger, addr, token string, a *api.API) *Server {
+	s := &Server{logger: logger, api: a, levels: newLevels(logger)}
+	s.SetToken(token)
+	s.SetConfig(config.Default())
+
+	router := mux.NewRouter().StrictSlash(true)
+
+	// setting up middlewares.
+	m := middlewares.New(logger)
+
+	router.Use(m.Correlate)
+	router.Use(m.RecoverPanic)
+	router.Use(m.LogInfo)
+	router.Use(s.authenticate)
+
+	// setup admin routes.
+	router.HandleFunc("/admin/loglevel", s.getLogLevel).Methods(http.MethodGet)
+	router.HandleFunc("/admin/loglevel", s.putLogLevel).Methods(http.MethodPut)
+	router.HandleFunc("/admin/config", s.getConfig).Methods(http.MethodGet)
+	router.HandleFunc("/admin/requests", s.getRequests).Methods(http.MethodGet)
+	router.Handle("/admin/debug/pprof/goroutine", pprof.Handler("goroutine")).Methods(http.MethodGet)
+	router.Handle("/admin/debug/pprof/heap", pprof.Handler("heap")).Methods(http.MethodGet)
+
+	s.handler = router
+	s.server = httpserver.New(logger, "admin", addr, router, a.Timeouts(), 0)
+
+	return s
+}
+
+// Handler returns the root handler of the admin server, e.g. to serve it with httptest.
+func (s *Server) Handler() http.Handler {
+	return s.handler
+}
+
+// Start listens on the address of the admin server and serves it in the background, see httpserver.Server.
+func (s *Server) Start(ctx context.Context) error {
+	return s.server.Start(ctx)
+}
+
+// Err receives the error the admin server fails with after it started.
+func (s *Server) Err() <-chan error {
+	return s.server.Err()
+}
+
+// Shutdown shuts the admin server down, see httpserver.Server. A time-limited log level is no longer
+// reverted.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.levels.stop()
+
+	return s.server.Shutdown(ctx)
+}
+
+// SetToken replaces the bearer token requests must carry.
+func (s *Server) SetToken(token string) {
+	s.token.Store(&token)
+}
+
+// SetConfig replaces the config served, which is redacted.
+func (s *Server) SetConfig(cfg config.Config) {
+	cfg = cfg.Redacted()
+	s.config.Store(&cfg)
+}
+
+// SetLevel sets the level the logger runs with, e.g. on reload, dropping a time-limited one.
+func (s *Server) SetLevel(lvl string) error {
+	return s.levels.set(lvl, 0)
+}
+
+// authenticate answers 401 to requests without the bearer token. Tokens are compared in constant time.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+		token := strings.TrimPrefix(header, "Bearer ")
+
+		if want := *s.token.Load(); token == header || want == "" || subtle.ConstantTimeCompare([]byte(token), []byte(want)) != 1 {
+			s.logger.Warn(r.Context(), nil, "admin request rejected: ", r.Method, " ", r.URL.Path, " from ", r.RemoteAddr)
+
+			w.Header().Set("WWW-Authenticate", `Bearer realm="ptask-admin"`)
+			response.Error(w, httperrors.ErrUnauthorized)
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) getConfig(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	cfg := s.config.Load()
+
+	b, err := cfg.YAML()
+	if err != nil {
+		s.logger.Error(ctx, err, "could not marshal config")
+		response.Error(w, err)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/yaml")
+	_, _ = w.Write(b)
+}
+
+func (s *Server) getRequests(w http.ResponseWriter, _ *http.Request) {
+	response.Success(w, http.StatusOK, s.api.InFlight())
+}
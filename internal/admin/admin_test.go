@@ -0,0 +1,206 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/ptask/internal/api"
+	"github.com/KarolosLykos/ptask/internal/config"
+	"github.com/KarolosLykos/ptask/internal/logger"
+	"github.com/KarolosLykos/ptask/internal/logger/log"
+	"github.com/KarolosLykos/ptask/internal/ptask/usecase"
+)
+
+const token = "secret"
+
+func TestServer_Authenticate(t *testing.T) {
+	tt := []struct {
+		name   string
+		header string
+		status int
+	}{
+		{name: "token", header: "Bearer " + token, status: http.StatusOK},
+		{name: "no token", status: http.StatusUnauthorized},
+		{name: "wrong token", header: "Bearer secrets", status: http.StatusUnauthorized},
+		{name: "not a bearer token", header: token, status: http.StatusUnauthorized},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newServer(getLogger())
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/loglevel", nil)
+			if tc.header != "" {
+				req.Header.Set("Authorization", tc.header)
+			}
+
+			w := httptest.NewRecorder()
+			s.Handler().ServeHTTP(w, req)
+
+			assert.Equal(t, tc.status, w.Code)
+
+			if tc.status == http.StatusUnauthorized {
+				assert.Equal(t, `Bearer realm="ptask-admin"`, w.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+
+	t.Run("rotated token", func(t *testing.T) {
+		s := newServer(getLogger())
+		s.SetToken("rotated")
+
+		assert.Equal(t, http.StatusUnauthorized, do(t, s, http.MethodGet, "/admin/loglevel", "").Code)
+	})
+}
+
+func TestServer_LogLevel(t *testing.T) {
+	tt := []struct {
+		name   string
+		body   string
+		status int
+		level  string
+	}{
+		{name: "level", body: `{"level":"debug"}`, status: http.StatusOK, level: "debug"},
+		{name: "unknown level", body: `{"level":"loud"}`, status: http.StatusBadRequest, level: "info"},
+		{name: "invalid body", body: `{"level":`, status: http.StatusBadRequest, level: "info"},
+		{name: "invalid duration", body: `{"level":"debug","duration":"soon"}`, status: http.StatusBadRequest, level: "info"},
+		{name: "negative duration", body: `{"level":"debug","duration":"-1m"}`, status: http.StatusBadRequest, level: "info"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			l := getLogger()
+			s := newServer(l)
+
+			w := do(t, s, http.MethodPut, "/admin/loglevel", tc.body)
+
+			assert.Equal(t, tc.status, w.Code)
+			assert.Equal(t, tc.level, l.Level())
+		})
+	}
+}
+
+func TestServer_LogLevel_Revert(t *testing.T) {
+	l := getLogger()
+	s := newServer(l)
+
+	w := do(t, s, http.MethodPut, "/admin/loglevel", `{"level":"debug","duration":"1h"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	res := struct {
+		Data LogLevel `json:"data"`
+	}{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+
+	assert.Equal(t, "debug", res.Data.Level)
+	assert.Equal(t, "info", res.Data.Base)
+	require.NotNil(t, res.Data.RevertAt)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), *res.Data.RevertAt, time.Minute)
+
+	// a new time-limited level replaces the pending one and reverts to the level before both.
+	require.Equal(t, http.StatusOK, do(t, s, http.MethodPut, "/admin/loglevel", `{"level":"trace","duration":"50ms"}`).Code)
+	assert.Equal(t, "trace", l.Level())
+
+	assert.Eventually(t, func() bool { return l.Level() == "info" }, time.Second, 10*time.Millisecond)
+
+	w = do(t, s, http.MethodGet, "/admin/loglevel", "")
+	assert.JSONEq(t, `{"status":"success","data":{"level":"info","base":"info"}}`, w.Body.String())
+
+	// a level set for good cancels the revert.
+	require.Equal(t, http.StatusOK, do(t, s, http.MethodPut, "/admin/loglevel", `{"level":"warn","duration":"50ms"}`).Code)
+	require.NoError(t, s.SetLevel("error"))
+
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, "error", l.Level())
+}
+
+func TestServer_Config(t *testing.T) {
+	s := newServer(getLogger())
+
+	cfg := config.Default()
+	cfg.Admin = config.Admin{Host: "127.0.0.1", Port: "8081", Token: token}
+	s.SetConfig(cfg)
+
+	w := do(t, s, http.MethodGet, "/admin/config", "")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/yaml", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "port: \"8081\"")
+	assert.Contains(t, w.Body.String(), "token: REDACTED")
+	assert.NotContains(t, w.Body.String(), token)
+}
+
+func TestServer_Requests(t *testing.T) {
+	s := newServer(getLogger())
+
+	w := do(t, s, http.MethodGet, "/admin/requests", "")
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status":"success","data":[]}`, w.Body.String())
+}
+
+func TestServer_Pprof(t *testing.T) {
+	s := newServer(getLogger())
+
+	for _, profile := range []string{"goroutine", "heap"} {
+		t.Run(profile, func(t *testing.T) {
+			w := do(t, s, http.MethodGet, "/admin/debug/pprof/"+profile+"?debug=1", "")
+
+			require.Equal(t, http.StatusOK, w.Code)
+			assert.NotEmpty(t, w.Body.String())
+		})
+	}
+}
+
+func TestServer_Start(t *testing.T) {
+	s := New(getLogger(), "127.0.0.1:0", token, api.New(getLogger(), "127.0.0.1:0", usecase.NewPeriodicTaskUC(getLogger())))
+
+	require.NoError(t, s.Start(context.Background()))
+	require.NoError(t, s.Shutdown(context.Background()))
+
+	select {
+	case err := <-s.Err():
+		t.Fatalf("unexpected error: %v", err)
+	default:
+	}
+}
+
+func newServer(l logger.Logger) *Server {
+	return New(l, "127.0.0.1:0", token, api.New(l, "127.0.0.1:0", usecase.NewPeriodicTaskUC(l)))
+}
+
+func do(t *testing.T, s *Server, method, target, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	w := httptest.NewRecorder()
+	s.Handler().ServeHTTP(w, req)
+
+	return w
+}
+
+func getLogger() logger.Logger {
+	l := &logrus.Logger{
+		Out:          io.Discard,
+		Hooks:        make(logrus.LevelHooks),
+		ReportCaller: false,
+		ExitFunc:     os.Exit,
+		Level:        logrus.InfoLevel,
+		Formatter:    &logrus.JSONFormatter{},
+	}
+
+	return log.New(l)
+}
@@ -0,0 +1,175 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/KarolosLykos/ptask/internal/logger"
+	"github.com/KarolosLykos/ptask/internal/utils/httperrors"
+	"github.com/KarolosLykos/ptask/internal/utils/response"
+)
+
+// LogLevel is the level the logger runs with. A time-limited level reverts to Base at RevertAt.
+type LogLevel struct {
+	Level    string     `json:"level"`
+	Base     string     `json:"base"`
+	RevertAt *time.Time `json:"revert_at,omitempty"`
+}
+
+// LogLevelRequest sets the level of the logger, for Duration only when it is set, e.g. "15m".
+type LogLevelRequest struct {
+	Level    string `json:"level"`
+	Duration string `json:"duration,omitempty"`
+}
+
+// levels sets the level of the logger and reverts the time-limited ones.
+type levels struct {
+	logger logger.Logger
+
+	mu       sync.Mutex
+	base     string
+	revert   *time.Timer
+	revertAt time.Time
+	// generation tells a revert apart from the ones replaced while it fired.
+	generation uint64
+}
+
+func newLevels(logger logger.Logger) *levels {
+	return &levels{logger: logger}
+}
+
+// set sets the level of the logger, reverting it after d unless d is 0. Setting a level while a
+// time-limited one is pending replaces it, the level reverted to being the one before the first.
+func (l *levels) set(lvl string, d time.Duration) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	current := l.logger.Level()
+
+	if err := l.logger.SetLevel(lvl); err != nil {
+		return httperrors.Wrap(httperrors.ErrInvalidLogLevel, err)
+	}
+
+	pending := l.stopLocked()
+
+	if d == 0 {
+		l.base = ""
+		return nil
+	}
+
+	if !pending {
+		l.base = current
+	}
+
+	l.generation++
+	generation := l.generation
+
+	l.revertAt = time.Now().Add(d)
+	l.revert = time.AfterFunc(d, func() { l.expire(generation) })
+
+	return nil
+}
+
+// expire reverts the time-limited level of generation, unless it was replaced.
+func (l *levels) expire(generation uint64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.revert == nil || l.generation != generation {
+		return
+	}
+
+	ctx := context.Background()
+
+	if err := l.logger.SetLevel(l.base); err != nil {
+		l.logger.Error(ctx, err, "could not revert log level")
+	} else {
+		l.logger.Info(ctx, "log level reverted to: ", l.base)
+	}
+
+	l.revert, l.base = nil, ""
+}
+
+// stop cancels the revert of a time-limited level.
+func (l *levels) stop() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.stopLocked()
+}
+
+// stopLocked cancels the revert of a time-limited level and reports whether one was pending.
+func (l *levels) stopLocked() bool {
+	if l.revert == nil {
+		return false
+	}
+
+	l.revert.Stop()
+	l.revert = nil
+
+	return true
+}
+
+func (l *levels) get() *LogLevel {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	res := &LogLevel{Level: l.logger.Level(), Base: l.logger.Level()}
+
+	if l.revert != nil {
+		revertAt := l.revertAt
+		res.Base, res.RevertAt = l.base, &revertAt
+	}
+
+	return res
+}
+
+func (s *Server) getLogLevel(w http.ResponseWriter, _ *http.Request) {
+	response.Success(w, http.StatusOK, s.levels.get())
+}
+
+func (s *Server) putLogLevel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := &LogLevelRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		s.logger.Error(ctx, err, "could not decode log level")
+		response.Error(w, httperrors.Wrap(httperrors.ErrInvalidLogLevel, err))
+
+		return
+	}
+
+	var d time.Duration
+
+	if req.Duration != "" {
+		var err error
+
+		if d, err = time.ParseDuration(req.Duration); err != nil || d <= 0 {
+			err = httperrors.Wrapf(httperrors.ErrInvalidDuration, "%q is not a positive duration", req.Duration)
+			s.logger.Error(ctx, err, "invalid log level duration")
+			response.Error(w, err)
+
+			return
+		}
+	}
+
+	if err := s.levels.set(req.Level, d); err != nil {
+		s.logger.Error(ctx, err, "could not set log level")
+		response.Error(w, err)
+
+		return
+	}
+
+	res := s.levels.get()
+
+	if res.RevertAt != nil {
+		s.logger.Info(ctx, "log level set to: ", res.Level, " until ", res.RevertAt.Format(time.RFC3339))
+	} else {
+		s.logger.Info(ctx, "log level set to: ", res.Level)
+	}
+
+	response.Success(w, http.StatusOK, res)
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/KarolosLykos/ptask/internal/logger"
+	"github.com/KarolosLykos/ptask/internal/utils/httperrors"
+	"github.com/KarolosLykos/ptask/internal/utils/response"
+)
+
+const (
+	statusOK          = "ok"
+	statusUnavailable = "unavailable"
+	statusDraining    = "draining"
+)
+
+// Check reports whether a dependency of the service, e.g. a store, can serve requests.
+type Check func(ctx context.Context) error
+
+type Health struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// health answers the probes of the orchestrator. The service stops being ready once it drains, or while a
+// dependency fails its check.
+type health struct {
+	logger   logger.Logger
+	checks   map[string]Check
+	draining atomic.Bool
+}
+
+func newHealth(logger logger.Logger) *health {
+	return &health{logger: logger, checks: map[string]Check{}}
+}
+
+// healthz reports whether the service is alive
+//
+//	@Summary		Returns 200 as long as the service is alive.
+//	@Tags			v1,v2
+//	@Produce		json
+//	@Success		200
+//
+//	@Router			/healthz [get]
+func (h *health) healthz(w http.ResponseWriter, _ *http.Request) {
+	response.Success(w, http.StatusOK, &Health{Status: statusOK})
+}
+
+// readyz reports whether the service is ready to serve requests
+//
+//	@Summary		Returns 200 when the service and its dependencies are ready, 503 once it drains or while a dependency fails.
+//	@Tags			v1,v2
+//	@Produce		json
+//	@Success		200
+//	@Failure		503
+//
+//	@Router			/readyz [get]
+func (h *health) readyz(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if h.draining.Load() {
+		response.ErrorWithDetails(w, httperrors.Wrapf(httperrors.ErrNotReady, statusDraining), &Health{Status: statusDraining})
+		return
+	}
+
+	res := &Health{Status: statusOK}
+
+	if len(h.checks) > 0 {
+		res.Checks = make(map[string]string, len(h.checks))
+	}
+
+	for name, check := range h.checks {
+		// the cause is logged, the probe only tells which dependency failed.
+		if err := check(ctx); err != nil {
+			h.logger.Error(ctx, err, "readiness check failed: ", name)
+
+			res.Status, res.Checks[name] = statusUnavailable, statusUnavailable
+
+			continue
+		}
+
+		res.Checks[name] = statusOK
+	}
+
+	if res.Status != statusOK {
+		response.ErrorWithDetails(w, httperrors.ErrNotReady, res)
+		return
+	}
+
+	response.Success(w, http.StatusOK, res)
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/ptask/internal/logger"
+	"github.com/KarolosLykos/ptask/internal/logger/log"
+	"github.com/KarolosLykos/ptask/internal/ptask/usecase"
+	"github.com/KarolosLykos/ptask/internal/tzdata"
+)
+
+func TestAPI_Health(t *testing.T) {
+	storeErr := errors.New("connection refused")
+
+	tt := []struct {
+		name   string
+		opts   []Option
+		drain  bool
+		path   string
+		status int
+		data   string
+	}{
+		{name: "healthz", path: "/healthz", status: http.StatusOK, data: `{"status":"ok"}`},
+		{name: "healthz while draining", path: "/healthz", drain: true, status: http.StatusOK, data: `{"status":"ok"}`},
+		{name: "readyz", path: "/readyz", status: http.StatusOK, data: `{"status":"ok"}`},
+		{
+			name:   "readyz with checks",
+			opts:   []Option{WithReadinessCheck("store", func(context.Context) error { return nil })},
+			path:   "/readyz",
+			status: http.StatusOK,
+			data:   `{"status":"ok","checks":{"store":"ok"}}`,
+		},
+		{
+			name: "readyz with a failing check",
+			opts: []Option{
+				WithReadinessCheck("store", func(context.Context) error { return storeErr }),
+				WithReadinessCheck("scheduler", func(context.Context) error { return nil }),
+			},
+			path:   "/readyz",
+			status: http.StatusServiceUnavailable,
+			data:   `{"status":"unavailable","checks":{"scheduler":"ok","store":"unavailable"}}`,
+		},
+		{name: "readyz while draining", path: "/readyz", drain: true, status: http.StatusServiceUnavailable, data: `{"status":"draining"}`},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			a := New(getLogger(), "127.0.0.1:0", usecase.NewPeriodicTaskUC(getLogger()), tc.opts...)
+
+			if tc.drain {
+				a.Drain(context.Background())
+			}
+
+			w := httptest.NewRecorder()
+			a.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.path, nil))
+
+			require.Equal(t, tc.status, w.Code)
+
+			res := struct {
+				Data json.RawMessage `json:"data"`
+			}{}
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+			assert.JSONEq(t, tc.data, string(res.Data))
+		})
+	}
+}
+
+func TestAPI_Version(t *testing.T) {
+	a := New(getLogger(), "127.0.0.1:0", usecase.NewPeriodicTaskUC(getLogger()))
+
+	w := httptest.NewRecorder()
+	a.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	res := struct {
+		Data Version `json:"data"`
+	}{}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+
+	assert.Equal(t, runtime.Version(), res.Data.GoVersion)
+	assert.Equal(t, tzdata.Version(), res.Data.Tzdata)
+	assert.Equal(t, tzdata.Source(), res.Data.TzdataSource)
+}
+
+func getLogger() logger.Logger {
+	l := &logrus.Logger{
+		Out:          io.Discard,
+		Hooks:        make(logrus.LevelHooks),
+		ReportCaller: false,
+		ExitFunc:     os.Exit,
+		Level:        logrus.DebugLevel,
+		Formatter:    &logrus.JSONFormatter{},
+	}
+
+	return log.New(l)
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/KarolosLykos/ptask/internal/correlation"
+)
+
+// Request is a request the API is serving.
+type Request struct {
+	RequestID  string        `json:"request_id"`
+	TraceID    string        `json:"trace_id,omitempty"`
+	Method     string        `json:"method"`
+	Route      string        `json:"route"`
+	Target     string        `json:"target"`
+	RemoteAddr string        `json:"remote_addr"`
+	Started    time.Time     `json:"started"`
+	Duration   time.Duration `json:"duration_ns"`
+}
+
+// inFlight tracks the requests being served, e.g. to find the ones that hang.
+type inFlight struct {
+	mu       sync.Mutex
+	next     uint64
+	requests map[uint64]Request
+}
+
+func newInFlight() *inFlight {
+	return &inFlight{requests: map[uint64]Request{}}
+}
+
+// track records a request from the time its route matched until it is served. It must run after Correlate,
+// so the request is identified.
+func (f *inFlight) track(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := Request{
+			Method:     r.Method,
+			Route:      r.URL.Path,
+			Target:     r.URL.RequestURI(),
+			RemoteAddr: r.RemoteAddr,
+			Started:    time.Now(),
+		}
+
+		if route := mux.CurrentRoute(r); route != nil {
+			if template, err := route.GetPathTemplate(); err == nil {
+				req.Route = template
+			}
+		}
+
+		if ids, ok := correlation.FromContext(r.Context()); ok {
+			req.RequestID, req.TraceID = ids.RequestID, ids.TraceID
+		}
+
+		f.mu.Lock()
+		id := f.next
+		f.next++
+		f.requests[id] = req
+		f.mu.Unlock()
+
+		defer func() {
+			f.mu.Lock()
+			delete(f.requests, id)
+			f.mu.Unlock()
+		}()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// list returns the requests being served, the oldest first, with the time they have been running for.
+func (f *inFlight) list() []Request {
+	now := time.Now()
+
+	f.mu.Lock()
+	requests := make([]Request, 0, len(f.requests))
+
+	for _, req := range f.requests {
+		req.Duration = now.Sub(req.Started)
+		requests = append(requests, req)
+	}
+	f.mu.Unlock()
+
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].Started.Before(requests[j].Started)
+	})
+
+	return requests
+}
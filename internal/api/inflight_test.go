@@ -0,0 +1,56 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/ptask/internal/api/middlewares"
+	"github.com/KarolosLykos/ptask/internal/constants"
+)
+
+func TestInFlight(t *testing.T) {
+	f := newInFlight()
+
+	started, release := make(chan struct{}), make(chan struct{})
+
+	router := mux.NewRouter()
+	router.Use(middlewares.New(getLogger()).Correlate)
+	router.Use(f.track)
+	router.HandleFunc("/tasks/{id}", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	})
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		req := httptest.NewRequest(http.MethodGet, "/tasks/1?tz=UTC", nil)
+		req.Header.Set(constants.RequestIDHeader, "hanging")
+
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}()
+
+	<-started
+
+	requests := f.list()
+	require.Len(t, requests, 1)
+
+	assert.Equal(t, "hanging", requests[0].RequestID)
+	assert.Equal(t, http.MethodGet, requests[0].Method)
+	assert.Equal(t, "/tasks/{id}", requests[0].Route)
+	assert.Equal(t, "/tasks/1?tz=UTC", requests[0].Target)
+	assert.NotEmpty(t, requests[0].TraceID)
+	assert.Positive(t, requests[0].Duration)
+
+	close(release)
+	<-done
+
+	assert.Empty(t, f.list())
+}
@@ -6,7 +6,15 @@ import (
 	"strings"
 	"time"
 
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/KarolosLykos/ptask/internal/constants"
+	"github.com/KarolosLykos/ptask/internal/correlation"
 	"github.com/KarolosLykos/ptask/internal/logger"
+	"github.com/KarolosLykos/ptask/internal/tracing"
+	"github.com/KarolosLykos/ptask/internal/tzdata"
 	"github.com/KarolosLykos/ptask/internal/utils/httperrors"
 	"github.com/KarolosLykos/ptask/internal/utils/response"
 )
@@ -19,6 +27,34 @@ func New(logger logger.Logger) *middleware {
 	return &middleware{logger: logger}
 }
 
+// Correlate starts the span of a request, child of the one of the traceparent it was sent with, identifies
+// the request with the X-Request-ID it was sent with, or a generated one, logs both with every entry of the
+// request and echoes them in the response.
+func (m *middleware) Correlate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := r.URL.Path
+		if current := mux.CurrentRoute(r); current != nil {
+			if template, err := current.GetPathTemplate(); err == nil {
+				route = template
+			}
+		}
+
+		ctx, span := tracing.StartServer(r.Context(), propagation.HeaderCarrier(r.Header), r.Method+" "+route,
+			attribute.String("http.method", r.Method),
+			attribute.String("http.route", route),
+			attribute.String("http.target", r.URL.RequestURI()),
+		)
+		defer span.End()
+
+		ids := correlation.New(r.Header.Get(constants.RequestIDHeader), span.SpanContext())
+
+		w.Header().Set(constants.RequestIDHeader, ids.RequestID)
+		w.Header().Set(constants.TraceParentHeader, ids.TraceParent)
+
+		next.ServeHTTP(w, r.WithContext(correlation.WithIDs(ctx, ids)))
+	})
+}
+
 // RecoverPanic middleware handle any panic that may occur.
 func (m *middleware) RecoverPanic(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -26,7 +62,7 @@ func (m *middleware) RecoverPanic(next http.Handler) http.Handler {
 
 		defer func() {
 			if err := recover(); err != nil {
-				m.logger.Error(ctx, fmt.Errorf("%w: %v", httperrors.ErrRecoverPanic, err), "middleware recovering from panic error")
+				m.logger.Error(ctx, httperrors.Wrapf(httperrors.ErrRecoverPanic, "%v", err), "middleware recovering from panic error")
 				response.Error(w, httperrors.ErrRecoverPanic)
 			}
 		}()
@@ -42,9 +78,34 @@ func (m *middleware) LogInfo(next http.Handler) http.Handler {
 
 		if !strings.Contains(r.RequestURI, "/swagger/") {
 			start := time.Now()
-			defer m.logger.Info(ctx, fmt.Sprintf("%s %s took %s", r.Method, r.RequestURI, time.Since(start).String()))
+			defer func() {
+				m.logger.Info(ctx, fmt.Sprintf("%s %s took %s", r.Method, r.RequestURI, time.Since(start).String()))
+			}()
 		}
 
 		next.ServeHTTP(w, r)
 	})
 }
+
+// TzdataVersion reports the release of the tz database occurrences are computed with.
+func (m *middleware) TzdataVersion(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(constants.TzdataVersionHeader, tzdata.Version())
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// LimitBody makes reading the body of a request fail past the bound max returns when it starts, 0 not
+// bounding it.
+func (m *middleware) LimitBody(max func() int64) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if n := max(); n > 0 {
+				r.Body = http.MaxBytesReader(w, r.Body, n)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/ptask/internal/correlation"
+	"github.com/KarolosLykos/ptask/internal/logger"
+	"github.com/KarolosLykos/ptask/internal/logger/log"
+	"github.com/KarolosLykos/ptask/internal/utils/httperrors"
+	"github.com/KarolosLykos/ptask/internal/utils/response"
+)
+
+func TestMiddleware_Correlate(t *testing.T) {
+	m := New(getLogger())
+
+	h := m.Correlate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids, ok := correlation.FromContext(r.Context())
+		require.True(t, ok)
+		assert.Equal(t, ids.RequestID, logger.FieldsFrom(r.Context())["request_id"])
+
+		response.Error(w, httperrors.ErrInvalidPeriod)
+	}))
+
+	t.Run("accepted", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/v1/ptlist", nil)
+		r.Header.Set("X-Request-ID", "req-1")
+		r.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		assert.Equal(t, "req-1", w.Header().Get("X-Request-ID"))
+		assert.Regexp(t, `^00-4bf92f3577b34da6a3ce929d0e0e4736-[0-9a-f]{16}-01$`, w.Header().Get("traceparent"))
+
+		res := &response.Response{}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(res))
+		assert.Equal(t, "req-1", res.RequestID)
+	})
+
+	t.Run("generated", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/ptlist", nil))
+
+		assert.Regexp(t, `^[0-9a-f]{32}$`, w.Header().Get("X-Request-ID"))
+		assert.Regexp(t, `^00-[0-9a-f]{32}-[0-9a-f]{16}-01$`, w.Header().Get("traceparent"))
+	})
+}
+
+func TestMiddleware_LimitBody(t *testing.T) {
+	m := New(getLogger())
+
+	h := m.LimitBody(func() int64 { return 4 })(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+		}
+	}))
+
+	for body, status := range map[string]int{"1234": http.StatusOK, "12345": http.StatusRequestEntityTooLarge} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/ptlist/compose", strings.NewReader(body)))
+
+		assert.Equal(t, status, w.Code, body)
+	}
+}
+
+func getLogger() logger.Logger {
+	l := &logrus.Logger{
+		Out:          io.Discard,
+		Hooks:        make(logrus.LevelHooks),
+		ReportCaller: false,
+		ExitFunc:     os.Exit,
+		Level:        logrus.DebugLevel,
+		Formatter:    &logrus.JSONFormatter{},
+	}
+
+	return log.New(l)
+}
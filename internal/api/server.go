@@ -2,43 +2,110 @@ package api
 
 import (
 	"context"
-	"errors"
-	"fmt"
 	"net/http"
-	"time"
+	"sync/atomic"
 
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
-	httpSwagger "github.com/swaggo/http-swagger"
 
-	_ "github.com/KarolosLykos/ptask/docs"
 	"github.com/KarolosLykos/ptask/internal/api/middlewares"
+	"github.com/KarolosLykos/ptask/internal/constants"
+	"github.com/KarolosLykos/ptask/internal/httpserver"
 	"github.com/KarolosLykos/ptask/internal/logger"
+	"github.com/KarolosLykos/ptask/internal/metrics"
 	"github.com/KarolosLykos/ptask/internal/ptask"
+	"github.com/KarolosLykos/ptask/internal/ptask/gql"
 	taskHttp "github.com/KarolosLykos/ptask/internal/ptask/http"
+	"github.com/KarolosLykos/ptask/internal/utils/response"
 )
 
 type API struct {
-	logger  logger.Logger
-	addr    string
-	handler http.Handler
-	server  *http.Server
+	logger   logger.Logger
+	addr     string
+	handler  http.Handler
+	server   *httpserver.Server
+	health   *health
+	inFlight *inFlight
+	timeouts Timeouts
+	limits   Limits
+
+	// settings that can change while serving.
+	maxBodyBytes atomic.Int64
+	origins      atomic.Pointer[[]string]
 }
 
-func New(logger logger.Logger, addr string, useCase ptask.UseCase) *API {
+// Limits bound the size of the header and of the body of requests. Zero keeps the default of net/http for
+// headers and does not bound bodies. Only the bound of bodies can change while serving, see SetMaxBodyBytes.
+type Limits struct {
+	MaxHeaderBytes int
+	MaxBodyBytes   int64
+}
+
+// Timeouts bound the server of the API, httpserver.DefaultTimeouts unless WithTimeouts replaces them.
+type Timeouts = httpserver.Timeouts
+
+// Option configures the API.
+type Option func(*API)
+
+// WithTimeouts replaces the default timeouts of the server.
+func WithTimeouts(timeouts Timeouts) Option {
+	return func(a *API) {
+		a.timeouts = timeouts
+	}
+}
+
+// WithLimits bounds the size of requests.
+func WithLimits(limits Limits) Option {
+	return func(a *API) {
+		a.limits = limits
+		a.maxBodyBytes.Store(limits.MaxBodyBytes)
+	}
+}
+
+// WithCORSOrigins restricts the origins allowed to call the API, any by default.
+func WithCORSOrigins(origins ...string) Option {
+	return func(a *API) {
+		a.SetCORSOrigins(origins...)
+	}
+}
+
+// WithReadinessCheck makes the readiness of the API depend on the check of the named dependency.
+func WithReadinessCheck(name string, check Check) Option {
+	return func(a *API) {
+		a.health.checks[name] = check
+	}
+}
+
+func New(logger logger.Logger, addr string, useCase ptask.UseCase, opts ...Option) *API {
+	a := &API{logger: logger, addr: addr, health: newHealth(logger), inFlight: newInFlight(), timeouts: httpserver.DefaultTimeouts}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
 	// setting up cors options.
 	corsOptions := []handlers.CORSOption{
-		handlers.AllowedMethods([]string{http.MethodGet}),
-		handlers.AllowedHeaders([]string{"content-type"}),
+		handlers.AllowedMethods([]string{http.MethodGet, http.MethodPost}),
+		handlers.AllowedHeaders([]string{"content-type", constants.RequestIDHeader, constants.TraceParentHeader}),
+		handlers.ExposedHeaders([]string{constants.RequestIDHeader, constants.TraceParentHeader, constants.TzdataVersionHeader}),
+		handlers.AllowedOriginValidator(a.allowedOrigin),
 	}
 
 	router := mux.NewRouter().StrictSlash(true)
 
 	// setting up middlewares.
 	m := middlewares.New(logger)
+	met := metrics.New()
 
+	router.Use(response.ProblemMode)
+	router.Use(m.Correlate)
+	router.Use(met.Middleware)
+	router.Use(a.inFlight.track)
 	router.Use(m.RecoverPanic)
 	router.Use(m.LogInfo)
+	router.Use(m.TzdataVersion)
+
+	router.Use(m.LimitBody(a.maxBodyBytes.Load))
 
 	// init task handler.
 	h := taskHttp.NewTaskHandler(logger, useCase)
@@ -46,43 +113,93 @@ func New(logger logger.Logger, addr string, useCase ptask.UseCase) *API {
 	// setup task routes.
 	router = taskHttp.Routes(router, h)
 
-	// setup swagger route.
-	router.PathPrefix("/swagger/").Handler(httpSwagger.Handler(
-		httpSwagger.URL(fmt.Sprintf("http://%s/swagger/doc.json", addr)),
-		httpSwagger.DeepLinking(true),
-		httpSwagger.DocExpansion("none"),
-	)).Methods(http.MethodGet)
+	// setup graphql route.
+	g, err := gql.NewHandler(logger, useCase)
+	if err != nil {
+		logger.Panic(context.Background(), err, "could not build graphql schema")
+	}
+
+	router.Handle("/graphql", g).Methods(http.MethodGet, http.MethodPost)
+
+	// setup version and health routes.
+	router.HandleFunc("/version", version).Methods(http.MethodGet)
+	router.HandleFunc("/healthz", a.health.healthz).Methods(http.MethodGet)
+	router.HandleFunc("/readyz", a.health.readyz).Methods(http.MethodGet)
+
+	// setup metrics route.
+	router.Handle("/metrics", met.Handler()).Methods(http.MethodGet)
+
+	// setup swagger routes.
+	router.HandleFunc("/swagger/{version:v[0-9]+}/doc.json", swaggerDoc).Methods(http.MethodGet)
+	router.PathPrefix("/swagger/").Handler(swaggerUI(addr)).Methods(http.MethodGet)
 
 	// apply CORS middleware.
-	handler := handlers.CORS(corsOptions...)(router)
+	a.handler = handlers.CORS(corsOptions...)(router)
+	a.server = httpserver.New(logger, "http", addr, a.handler, a.timeouts, a.limits.MaxHeaderBytes)
+
+	return a
+}
+
+// Handler returns the root handler of the API, e.g. to serve it with httptest.
+func (a *API) Handler() http.Handler {
+	return a.handler
+}
+
+// Start listens on the address of the API and serves it in the background, see httpserver.Server.
+func (a *API) Start(ctx context.Context) error {
+	return a.server.Start(ctx)
+}
 
-	return &API{logger: logger, addr: addr, handler: handler}
+// Err receives the error the server fails with after it started.
+func (a *API) Err() <-chan error {
+	return a.server.Err()
 }
 
-func (a *API) Start(ctx context.Context) {
-	a.logger.Info(ctx, "starting server...")
+// Timeouts returns the timeouts the server of the API is bounded by.
+func (a *API) Timeouts() Timeouts {
+	return a.timeouts
+}
+
+// InFlight returns the requests being served, the oldest first.
+func (a *API) InFlight() []Request {
+	return a.inFlight.list()
+}
+
+// SetMaxBodyBytes replaces the bound of the bodies of the requests served from now on, 0 not bounding them.
+func (a *API) SetMaxBodyBytes(max int64) {
+	a.maxBodyBytes.Store(max)
+}
 
-	a.server = &http.Server{
-		Addr:         a.addr,
-		Handler:      a.handler,
-		WriteTimeout: time.Second * 15,
-		ReadTimeout:  time.Second * 15,
-		IdleTimeout:  time.Second * 15,
+// SetCORSOrigins replaces the origins allowed to call the API, "*" allowing any.
+func (a *API) SetCORSOrigins(origins ...string) {
+	origins = append([]string(nil), origins...)
+	a.origins.Store(&origins)
+}
+
+func (a *API) allowedOrigin(origin string) bool {
+	origins := a.origins.Load()
+	if origins == nil {
+		return true
 	}
 
-	go func() {
-		if err := a.server.ListenAndServe(); err != nil {
-			if !errors.Is(err, http.ErrServerClosed) {
-				a.logger.Panic(ctx, err, "could not start http server on: ", a.addr)
-			}
+	for _, o := range *origins {
+		if o == "*" || o == origin {
+			return true
 		}
-	}()
+	}
 
-	a.logger.Info(ctx, "server started on: ", a.addr)
+	return false
 }
 
-func (a *API) Shutdown(ctx context.Context) {
-	a.logger.Debug(ctx, "shutting down server...")
+// Drain makes the API report it is not ready, so load balancers stop sending it requests before it shuts
+// down. Requests keep being served.
+func (a *API) Drain(ctx context.Context) {
+	a.logger.Info(ctx, "draining server...")
+
+	a.health.draining.Store(true)
+}
 
-	_ = a.server.Shutdown(ctx)
+// Shutdown stops accepting requests and waits for the pending ones, see httpserver.Server.
+func (a *API) Shutdown(ctx context.Context) error {
+	return a.server.Shutdown(ctx)
 }
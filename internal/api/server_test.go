@@ -0,0 +1,64 @@
+package api
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/ptask/internal/ptask/usecase"
+)
+
+func TestAPI_Start(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer lis.Close()
+
+	// the address is in use.
+	a := New(getLogger(), lis.Addr().String(), usecase.NewPeriodicTaskUC(getLogger()))
+	assert.Error(t, a.Start(context.Background()))
+	assert.NoError(t, a.Shutdown(context.Background()))
+}
+
+func TestAPI_Shutdown(t *testing.T) {
+	addr := freeAddr(t)
+
+	a := New(getLogger(), addr, usecase.NewPeriodicTaskUC(getLogger()), WithTimeouts(Timeouts{
+		Read:       time.Second,
+		ReadHeader: time.Second,
+		Write:      time.Second,
+		Idle:       time.Second,
+	}))
+	require.NoError(t, a.Start(context.Background()))
+
+	res, err := http.Get("http://" + addr + "/healthz")
+	require.NoError(t, err)
+	_ = res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, time.Second, a.Timeouts().ReadHeader)
+
+	assert.NoError(t, a.Shutdown(context.Background()))
+
+	select {
+	case err := <-a.Err():
+		t.Fatalf("unexpected server error: %v", err)
+	default:
+	}
+}
+
+func freeAddr(t *testing.T) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer lis.Close()
+
+	return lis.Addr().String()
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	httpSwagger "github.com/swaggo/http-swagger"
+	"github.com/swaggo/swag"
+
+	"github.com/KarolosLykos/ptask/docs"
+)
+
+// versions are the API versions with a swagger document, registered by the docs package under their name.
+var versions = []string{"v1", "v2"}
+
+func init() {
+	// both documents are generated from the same general API info.
+	docs.SwaggerInfov2.Version = "2.0"
+}
+
+// swaggerUI serves the swagger UI, with a selector of the document of every version.
+func swaggerUI(addr string) http.Handler {
+	urls := ""
+	for i, v := range versions {
+		if i > 0 {
+			urls += ", "
+		}
+
+		urls += fmt.Sprintf(`{url: "http://%s/swagger/%s/doc.json", name: %q}`, addr, v, v)
+	}
+
+	return httpSwagger.Handler(
+		// the document of the unversioned routes.
+		httpSwagger.InstanceName(versions[0]),
+		httpSwagger.URL(fmt.Sprintf("http://%s/swagger/doc.json", addr)),
+		httpSwagger.UIConfig(map[string]string{"urls": "[" + urls + "]"}),
+		httpSwagger.DeepLinking(true),
+		httpSwagger.DocExpansion("none"),
+	)
+}
+
+// swaggerDoc serves the swagger document of a version.
+func swaggerDoc(w http.ResponseWriter, r *http.Request) {
+	doc, err := swag.ReadDoc(mux.Vars(r)["version"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	_, _ = w.Write([]byte(doc))
+}
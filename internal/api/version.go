@@ -0,0 +1,37 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/KarolosLykos/ptask/internal/buildinfo"
+	"github.com/KarolosLykos/ptask/internal/tzdata"
+	"github.com/KarolosLykos/ptask/internal/utils/response"
+)
+
+type Version struct {
+	Commit       string `json:"commit,omitempty"`
+	BuildTime    string `json:"build_time,omitempty"`
+	GoVersion    string `json:"go_version"`
+	Tzdata       string `json:"tzdata"`
+	TzdataSource string `json:"tzdata_source"`
+}
+
+// version returns the versions the service runs with
+//
+//	@Summary		Returns the git commit and time of the build, the Go version and the version of the tz database the service runs with.
+//	@Tags			v1,v2
+//	@Produce		json
+//	@Success		200
+//
+//	@Router			/version [get]
+func version(w http.ResponseWriter, _ *http.Request) {
+	build := buildinfo.Get()
+
+	response.Success(w, http.StatusOK, &Version{
+		Commit:       build.Commit,
+		BuildTime:    build.Time,
+		GoVersion:    build.GoVersion,
+		Tzdata:       tzdata.Version(),
+		TzdataSource: tzdata.Source(),
+	})
+}
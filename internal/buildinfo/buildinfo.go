@@ -0,0 +1,41 @@
+// Package buildinfo reports the build of the binary. Commit and Time are set at link time:
+//
+//	go build -ldflags "-X github.com/KarolosLykos/ptask/internal/buildinfo.Commit=$(git rev-parse HEAD)"
+//
+// and otherwise read from the VCS stamp of the binary, when it has one.
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+var (
+	Commit string
+	Time   string
+)
+
+// Info describes the build of the binary.
+type Info struct {
+	Commit    string
+	Time      string
+	GoVersion string
+}
+
+// Get returns the build of the binary. Unknown fields are empty.
+func Get() Info {
+	info := Info{Commit: Commit, Time: Time, GoVersion: runtime.Version()}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, s := range bi.Settings {
+			switch {
+			case s.Key == "vcs.revision" && info.Commit == "":
+				info.Commit = s.Value
+			case s.Key == "vcs.time" && info.Time == "":
+				info.Time = s.Value
+			}
+		}
+	}
+
+	return info
+}
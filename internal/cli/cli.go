@@ -0,0 +1,170 @@
+// Package cli implements the subcommands of the ptask binary.
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/KarolosLykos/ptask/internal/logger"
+	"github.com/KarolosLykos/ptask/internal/logger/log"
+	"github.com/KarolosLykos/ptask/internal/ptask"
+	"github.com/KarolosLykos/ptask/internal/ptask/usecase"
+	"github.com/KarolosLykos/ptask/internal/tzdata"
+	"github.com/KarolosLykos/ptask/internal/utils"
+)
+
+// Exit codes of Run.
+const (
+	ExitOK    = 0
+	ExitError = 1
+	ExitUsage = 2
+)
+
+var ErrUnknownCommand = errors.New("unknown command")
+
+const usage = `Usage: ptask <command> [flags]
+
+Commands:
+  list      print the occurrences of a periodic task in [t1, t2)
+  next      print the next occurrences of a periodic task
+  describe  print the human-readable description of a period
+  serve     run the HTTP API (the default when no command is given)
+
+Run 'ptask <command> -h' for the flags of a command.
+`
+
+type command func(ctx context.Context, args []string, stdout, stderr io.Writer) error
+
+var commands = map[string]command{
+	"list":     list,
+	"next":     next,
+	"describe": describe,
+	"serve":    serve,
+}
+
+// Run runs the command named by the first argument and returns the exit code of the process.
+// Arguments that do not start with a command are passed to serve, so the binary keeps running the
+// server when invoked with flags only.
+func Run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	name := "serve"
+
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
+	}
+
+	if name == "help" {
+		fmt.Fprint(stdout, usage)
+		return ExitOK
+	}
+
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(stderr, "ptask: %v: %s\n\n%s", ErrUnknownCommand, name, usage)
+		return ExitUsage
+	}
+
+	err := cmd(ctx, args, stdout, stderr)
+
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, flag.ErrHelp):
+		return ExitOK
+	case errors.Is(err, errUsage):
+		return ExitUsage
+	default:
+		fmt.Fprintf(stderr, "ptask: %v\n", err)
+		return ExitError
+	}
+}
+
+// errUsage reports flags that could not be parsed; the flag set has already printed why.
+var errUsage = errors.New("usage")
+
+// options holds the flags shared by the offline commands.
+type options struct {
+	debug     bool
+	format    string
+	blackouts string
+	tzdata    string
+}
+
+func newFlagSet(name string, stderr io.Writer, o *options) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	fs.BoolVar(&o.debug, "debug", false, "-debug")
+	fs.StringVar(&o.blackouts, "blackouts", "", "-blackouts blackouts.json")
+	fs.StringVar(&o.tzdata, "tzdata", "", "-tzdata zoneinfo.zip")
+
+	return fs
+}
+
+func parse(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+
+		return errUsage
+	}
+
+	if fs.NArg() > 0 {
+		fmt.Fprintf(fs.Output(), "unexpected arguments: %s\n", strings.Join(fs.Args(), " "))
+		fs.Usage()
+
+		return errUsage
+	}
+
+	return nil
+}
+
+// newUseCase builds the use case the server runs, logging to stderr so stdout only carries the output.
+func newUseCase(o *options, stderr io.Writer) (logger.Logger, ptask.UseCase, error) {
+	l := logrus.New()
+	l.SetOutput(stderr)
+	l.SetLevel(logrus.WarnLevel)
+
+	if o.debug {
+		l.SetLevel(logrus.TraceLevel)
+	}
+
+	lg := log.New(l)
+
+	if o.tzdata != "" {
+		if err := tzdata.LoadOverride(o.tzdata); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var opts []usecase.Option
+
+	if o.blackouts != "" {
+		sets, err := utils.ReadBlackoutSets(o.blackouts)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		opts = append(opts, usecase.WithBlackoutSets(sets))
+	}
+
+	return lg, usecase.NewPeriodicTaskUC(lg, opts...), nil
+}
+
+// stringSlice is a flag that can be repeated.
+type stringSlice []string
+
+func (s *stringSlice) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringSlice) Set(v string) error {
+	*s = append(*s, v)
+	return nil
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	now = func() time.Time { return time.Date(2021, 7, 28, 20, 46, 3, 0, time.UTC) }
+	defer func() { now = time.Now }()
+
+	window := []string{"-period", "1d", "-tz", "Europe/Athens", "-t1", "20210728T204603Z", "-t2", "20210731T123456Z"}
+
+	tt := []struct {
+		name   string
+		args   []string
+		code   int
+		stdout string
+		stderr string
+	}{
+		{name: "help", args: []string{"help"}, code: ExitOK, stdout: "Usage: ptask <command>"},
+		{name: "unknown command", args: []string{"bogus"}, code: ExitUsage, stderr: "unknown command: bogus"},
+		{name: "unknown flag", args: []string{"list", "-bogus"}, code: ExitUsage, stderr: "flag provided but not defined"},
+		{name: "unexpected arguments", args: []string{"list", "extra"}, code: ExitUsage, stderr: "unexpected arguments: extra"},
+		{name: "list text", args: append([]string{"list"}, window...), code: ExitOK, stdout: "20210728T210000Z\n20210729T210000Z\n20210730T210000Z\n"},
+		{
+			name:   "list json",
+			args:   append([]string{"list", "-format", "json"}, window...),
+			code:   ExitOK,
+			stdout: `{"status":"success","data":["20210728T210000Z","20210729T210000Z","20210730T210000Z"]}` + "\n",
+		},
+		{name: "list csv", args: append([]string{"list", "-format", "csv"}, window...), code: ExitOK, stdout: "timestamp\n20210728T210000Z\n20210729T210000Z\n20210730T210000Z\n"},
+		{name: "list ics", args: append([]string{"list", "-format", "ics"}, window...), code: ExitOK, stdout: "BEGIN:VEVENT\r\nUID:20210728T210000Z-1d@ptask\r\nDTSTAMP:20210728T204603Z\r\nDTSTART:20210728T210000Z\r\nSUMMARY:Every day at 00:00 (Europe/Athens)\r\nEND:VEVENT\r\n"},
+		{
+			name:   "list with blackout",
+			args:   append([]string{"list", "-blackout", "20210729T000000Z/20210730T000000Z"}, window...),
+			code:   ExitOK,
+			stdout: "20210728T210000Z\n20210730T210000Z\n",
+		},
+		{name: "list unknown format", args: append([]string{"list", "-format", "xml"}, window...), code: ExitError, stderr: "unsupported format:xml"},
+		{name: "list invalid period", args: []string{"list", "-period", "1w", "-t1", "20210728T204603Z", "-t2", "20210731T123456Z"}, code: ExitError, stderr: "invalid period"},
+		{name: "list unknown blackout set", args: append([]string{"list", "-blackout_set", "holidays"}, window...), code: ExitError, stderr: "unknown blackout set"},
+		{name: "next", args: []string{"next", "-period", "2h", "-tz", "Europe/Athens", "-count", "3"}, code: ExitOK, stdout: "20210728T210000Z\n20210728T230000Z\n20210729T010000Z\n"},
+		{name: "next from", args: []string{"next", "-period", "1mo", "-from", "20210728T204603Z", "-count", "2"}, code: ExitOK, stdout: "20210801T000000Z\n20210901T000000Z\n"},
+		{
+			name:   "next with blackout",
+			args:   []string{"next", "-period", "2h", "-tz", "Europe/Athens", "-count", "3", "-blackout", "20210728T220000Z/20210729T020000Z"},
+			code:   ExitOK,
+			stdout: "20210728T210000Z\n20210729T030000Z\n20210729T050000Z\n",
+		},
+		{
+			name:   "next past a long blackout",
+			args:   []string{"next", "-period", "1h", "-count", "2", "-blackout", "20210728T000000Z/20310101T000000Z"},
+			code:   ExitOK,
+			stdout: "20310101T000000Z\n20310101T010000Z\n",
+		},
+		{name: "next unknown blackout set", args: []string{"next", "-period", "1d", "-blackout_set", "holidays"}, code: ExitError, stderr: "unknown blackout set"},
+		{name: "next invalid count", args: []string{"next", "-period", "1d", "-count", "0"}, code: ExitError, stderr: "invalid count"},
+		{name: "describe", args: []string{"describe", "-period", "1mo", "-tz", "Europe/Athens", "-lang", "de"}, code: ExitOK, stdout: "Jeden Monat am 1. um 00:00 (Europe/Athens)\n"},
+		{
+			name:   "describe json",
+			args:   []string{"describe", "-period", "2d", "-format", "json"},
+			code:   ExitOK,
+			stdout: `{"status":"success","data":{"period":"2d","tz":"UTC","lang":"en","description":"Every 2 days at 00:00 (UTC)"}}` + "\n",
+		},
+		{name: "describe csv", args: []string{"describe", "-period", "2d", "-format", "csv"}, code: ExitError, stderr: "unsupported format"},
+		{name: "print config", args: []string{"-port", "8081", "-debug", "--print-config"}, code: ExitOK, stdout: "port: \"8081\""},
+		{name: "print config debug", args: []string{"serve", "-debug", "--print-config"}, code: ExitOK, stdout: "level: trace"},
+		{name: "invalid config", args: []string{"serve", "-port", "http", "--print-config"}, code: ExitError, stderr: `invalid config: server.port: "http" is not a port`},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			code := Run(context.Background(), tc.args, &stdout, &stderr)
+			assert.Equal(t, tc.code, code, stderr.String())
+
+			if tc.stdout != "" {
+				assert.True(t, strings.Contains(stdout.String(), tc.stdout), stdout.String())
+			}
+
+			if tc.stderr != "" {
+				assert.Contains(t, stderr.String(), tc.stderr)
+			}
+		})
+	}
+}
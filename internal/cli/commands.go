@@ -0,0 +1,405 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/KarolosLykos/ptask/docs"
+	"github.com/KarolosLykos/ptask/internal/admin"
+	"github.com/KarolosLykos/ptask/internal/api"
+	"github.com/KarolosLykos/ptask/internal/config"
+	"github.com/KarolosLykos/ptask/internal/constants"
+	"github.com/KarolosLykos/ptask/internal/logger/log"
+	"github.com/KarolosLykos/ptask/internal/ptask/usecase"
+	"github.com/KarolosLykos/ptask/internal/rpc"
+	"github.com/KarolosLykos/ptask/internal/tracing"
+	"github.com/KarolosLykos/ptask/internal/tzdata"
+	"github.com/KarolosLykos/ptask/internal/utils"
+	"github.com/KarolosLykos/ptask/internal/utils/httperrors"
+	"github.com/KarolosLykos/ptask/pkg/schedule"
+)
+
+// lookupEnv reads the environment variables of the config of serve.
+var lookupEnv = os.LookupEnv
+
+// now is the clock of next and of the DTSTAMP of calendars.
+var now = time.Now
+
+// nextHorizon is how long after the count-th occurrence next keeps looking for occurrences blackouts do not
+// suppress, so a blackout as long as the schedule does not keep it stepping forever.
+const nextHorizon = 100 * 365 * 24 * time.Hour
+
+func list(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var (
+		o                       options
+		period, tz, t1, t2      string
+		blackouts, blackoutSets stringSlice
+	)
+
+	fs := newFlagSet("list", stderr, &o)
+	fs.StringVar(&period, "period", "", "-period 1d")
+	fs.StringVar(&tz, "tz", "UTC", "-tz Europe/Athens")
+	fs.StringVar(&t1, "t1", "", "-t1 20210714T204603Z")
+	fs.StringVar(&t2, "t2", "", "-t2 20210715T123456Z")
+	fs.Var(&blackouts, "blackout", "-blackout 20210729T000000Z/20210730T000000Z (repeatable)")
+	fs.Var(&blackoutSets, "blackout_set", "-blackout_set holidays (repeatable)")
+	fs.StringVar(&o.format, "format", FormatText, "-format text|json|csv|ics")
+
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	l, useCase, err := newUseCase(&o, stderr)
+	if err != nil {
+		return err
+	}
+
+	params, err := utils.GetListQueryParams(ctx, l, period, tz, t1, t2)
+	if err != nil {
+		return err
+	}
+
+	if params.Blackouts, err = utils.GetBlackouts(ctx, l, blackouts); err != nil {
+		return err
+	}
+
+	params.BlackoutSets = blackoutSets
+
+	ptList, err := useCase.GetList(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	description, err := useCase.Describe(ctx, &utils.DescribeParams{Period: params.Period, Timezone: params.Timezone, Lang: constants.LangEnglish})
+	if err != nil {
+		return err
+	}
+
+	return writeList(stdout, o.format, ptList, description)
+}
+
+func next(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var (
+		o                       options
+		period, tz, from        string
+		count                   int
+		blackouts, blackoutSets stringSlice
+	)
+
+	fs := newFlagSet("next", stderr, &o)
+	fs.StringVar(&period, "period", "", "-period 1d")
+	fs.StringVar(&tz, "tz", "UTC", "-tz Europe/Athens")
+	fs.StringVar(&from, "from", "", "-from 20210714T204603Z (default now)")
+	fs.IntVar(&count, "count", constants.PreviewCount, "-count 5")
+	fs.Var(&blackouts, "blackout", "-blackout 20210729T000000Z/20210730T000000Z (repeatable)")
+	fs.Var(&blackoutSets, "blackout_set", "-blackout_set holidays (repeatable)")
+	fs.StringVar(&o.format, "format", FormatText, "-format text|json|csv|ics")
+
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	if count <= 0 || count > constants.MaxPreviewCount {
+		return httperrors.Wrapf(httperrors.ErrInvalidCount, "must be between 1 and %d", constants.MaxPreviewCount)
+	}
+
+	if from == "" {
+		from = now().UTC().Format(constants.TimestampLayout)
+	}
+
+	l, useCase, err := newUseCase(&o, stderr)
+	if err != nil {
+		return err
+	}
+
+	params, err := utils.GetListQueryParams(ctx, l, period, tz, from, from)
+	if err != nil {
+		return err
+	}
+
+	if params.Blackouts, err = utils.GetBlackouts(ctx, l, blackouts); err != nil {
+		return err
+	}
+
+	params.BlackoutSets = blackoutSets
+
+	// list the count first occurrences blackouts do not suppress, looking up to nextHorizon past the count-th
+	// occurrence of the task.
+	task, err := schedule.New(params.Period, params.Timezone, params.T1)
+	if err != nil {
+		return err
+	}
+
+	params.T2 = task.InvocationPoint
+	for i := 0; i < count; i++ {
+		if params.T2, err = task.Next(params.T2); err != nil {
+			return err
+		}
+	}
+
+	params.T2, params.Limit = params.T2.Add(nextHorizon), count
+
+	ptList, err := useCase.GetList(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	description, err := useCase.Describe(ctx, &utils.DescribeParams{Period: params.Period, Timezone: params.Timezone, Lang: constants.LangEnglish})
+	if err != nil {
+		return err
+	}
+
+	return writeList(stdout, o.format, ptList, description)
+}
+
+func describe(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var (
+		o                options
+		period, tz, lang string
+	)
+
+	fs := newFlagSet("describe", stderr, &o)
+	fs.StringVar(&period, "period", "", "-period 1d")
+	fs.StringVar(&tz, "tz", "UTC", "-tz Europe/Athens")
+	fs.StringVar(&lang, "lang", constants.LangEnglish, "-lang en|el|de")
+	fs.StringVar(&o.format, "format", FormatText, "-format text|json")
+
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	l, useCase, err := newUseCase(&o, stderr)
+	if err != nil {
+		return err
+	}
+
+	params, err := utils.GetDescribeQueryParams(ctx, l, period, tz, lang)
+	if err != nil {
+		return err
+	}
+
+	description, err := useCase.Describe(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	return writeDescription(stdout, o.format, description)
+}
+
+func serve(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	var (
+		cfg         = config.Default()
+		path        string
+		debug       bool
+		printConfig bool
+	)
+
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	cfg.RegisterFlags(fs)
+	fs.StringVar(&path, "config", "", "-config ptask.yaml (or "+config.EnvPrefix+"_CONFIG)")
+	fs.BoolVar(&debug, "debug", false, "-debug (same as -log-level trace)")
+	fs.BoolVar(&printConfig, "print-config", false, "-print-config prints the effective config and exits")
+
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+
+	if path == "" {
+		path, _ = lookupEnv(config.EnvPrefix + "_CONFIG")
+	}
+
+	if err := cfg.Load(path, lookupEnv, fs); err != nil {
+		return err
+	}
+
+	if debug {
+		cfg.Logging.Level = "trace"
+	}
+
+	if printConfig {
+		b, err := cfg.YAML()
+		if err != nil {
+			return err
+		}
+
+		_, err = stdout.Write(b)
+
+		return err
+	}
+
+	addr := cfg.Server.Host + ":" + cfg.Server.Port
+
+	docs.SwaggerInfov1.Host = addr
+	docs.SwaggerInfov2.Host = addr
+
+	// init logger.
+	logger := log.Default(false, cfg.Logging.Format)
+	_ = logger.SetLevel(cfg.Logging.Level) // validated by Load.
+
+	// load the tz database override.
+	if cfg.TZData != "" {
+		if err := tzdata.LoadOverride(cfg.TZData); err != nil {
+			return fmt.Errorf("could not load tzdata from %s: %w", cfg.TZData, err)
+		}
+	}
+
+	logger.Info(ctx, fmt.Sprintf("using tzdata %s (%s)", tzdata.Version(), tzdata.Source()))
+
+	// setup tracing.
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Config{
+		Exporter:    cfg.Tracing.Exporter,
+		Endpoint:    cfg.Tracing.Endpoint,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		return fmt.Errorf("could not setup tracing: %w", err)
+	}
+
+	// load named blackout sets, which are reloaded on SIGHUP.
+	blackouts := usecase.NewBlackouts(nil)
+
+	if cfg.Blackouts != "" {
+		sets, err := utils.ReadBlackoutSets(cfg.Blackouts)
+		if err != nil {
+			return fmt.Errorf("could not read blackout sets from %s: %w", cfg.Blackouts, err)
+		}
+
+		blackouts.Store(sets)
+	}
+
+	// init periodic task useCase.
+	useCase := usecase.NewPeriodicTaskUC(logger, usecase.WithBlackouts(blackouts))
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGQUIT, syscall.SIGTERM)
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+
+	defer signal.Stop(quit)
+	defer signal.Stop(hup)
+
+	// init servers.
+	s := api.New(logger, addr, useCase,
+		api.WithTimeouts(api.Timeouts{
+			Read:       cfg.Server.ReadTimeout,
+			ReadHeader: cfg.Server.ReadHeaderTimeout,
+			Write:      cfg.Server.WriteTimeout,
+			Idle:       cfg.Server.IdleTimeout,
+		}),
+		api.WithLimits(api.Limits{MaxHeaderBytes: cfg.Limits.MaxHeaderBytes, MaxBodyBytes: cfg.Limits.MaxBodyBytes}),
+		api.WithCORSOrigins(cfg.CORS.AllowedOrigins...),
+	)
+
+	var g *rpc.Server
+
+	if cfg.Server.GRPCPort != "" {
+		g = rpc.New(logger, cfg.Server.Host+":"+cfg.Server.GRPCPort, useCase)
+	}
+
+	var adm *admin.Server
+
+	if cfg.Admin.Port != "" {
+		adm = admin.New(logger, cfg.Admin.Host+":"+cfg.Admin.Port, cfg.Admin.Token, s)
+		adm.SetConfig(cfg)
+	}
+
+	// shutdown drains, in order and within the grace period, the HTTP requests, the gRPC calls and streams,
+	// the admin requests and the spans left.
+	shutdown := func() {
+		ctx, cancel := context.WithTimeout(ctx, cfg.Server.ShutdownTimeout)
+		defer cancel()
+
+		if err := s.Shutdown(ctx); err != nil {
+			logger.Error(ctx, err, "could not shut down http server in time")
+		}
+
+		if g != nil {
+			if err := g.Shutdown(ctx); err != nil {
+				logger.Error(ctx, err, "could not shut down grpc server in time")
+			}
+		}
+
+		if adm != nil {
+			if err := adm.Shutdown(ctx); err != nil {
+				logger.Error(ctx, err, "could not shut down admin server in time")
+			}
+		}
+
+		if err := shutdownTracing(ctx); err != nil {
+			logger.Error(ctx, err, "could not flush traces")
+		}
+	}
+
+	// start servers.
+	if err := s.Start(ctx); err != nil {
+		shutdown()
+		return err
+	}
+
+	var grpcErr <-chan error
+
+	if g != nil {
+		if err := g.Start(ctx); err != nil {
+			shutdown()
+			return err
+		}
+
+		grpcErr = g.Err()
+	}
+
+	var adminErr <-chan error
+
+	if adm != nil {
+		if err := adm.Start(ctx); err != nil {
+			shutdown()
+			return err
+		}
+
+		adminErr = adm.Err()
+	}
+
+	r := &reloader{logger: logger, cfg: &cfg, path: path, debug: debug, fs: fs, api: s, admin: adm, blackouts: blackouts}
+
+	var (
+		failed error
+		done   bool
+	)
+
+	for !done {
+		select {
+		case <-hup:
+			logger.Info(ctx, "received signal: hangup, reloading config")
+
+			if err := r.reload(ctx); err != nil {
+				logger.Error(ctx, err, "rejected config reload, keeping the running config")
+			}
+		case event := <-quit:
+			logger.Info(ctx, fmt.Sprintf("received signal: %v", event))
+
+			// fail readiness first, so load balancers stop sending requests before the server shuts down.
+			s.Drain(ctx)
+			time.Sleep(cfg.Server.DrainDelay)
+
+			done = true
+		case failed = <-s.Err():
+			done = true
+		case failed = <-grpcErr:
+			done = true
+		case failed = <-adminErr:
+			done = true
+		}
+	}
+
+	shutdown()
+
+	return failed
+}
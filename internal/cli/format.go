@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/KarolosLykos/ptask/internal/constants"
+	"github.com/KarolosLykos/ptask/internal/ptask/domain"
+	"github.com/KarolosLykos/ptask/internal/utils/response"
+)
+
+// Output formats.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+	FormatICS  = "ics"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported format")
+
+// writeList writes the timestamps of a task; the description titles the events of a calendar.
+func writeList(w io.Writer, format string, list domain.PtList, description *domain.PtDescription) error {
+	switch strings.ToLower(format) {
+	case FormatText:
+		for _, timestamp := range list {
+			if _, err := fmt.Fprintln(w, timestamp); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case FormatJSON:
+		return writeJSON(w, list)
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+
+		_ = cw.Write([]string{"timestamp"})
+		for _, timestamp := range list {
+			_ = cw.Write([]string{timestamp})
+		}
+
+		cw.Flush()
+
+		return cw.Error()
+	case FormatICS:
+		return writeICS(w, list, description)
+	default:
+		return fmt.Errorf("%w:%s", ErrUnsupportedFormat, format)
+	}
+}
+
+func writeDescription(w io.Writer, format string, description *domain.PtDescription) error {
+	switch strings.ToLower(format) {
+	case FormatText:
+		_, err := fmt.Fprintln(w, description.Description)
+		return err
+	case FormatJSON:
+		return writeJSON(w, description)
+	default:
+		return fmt.Errorf("%w:%s", ErrUnsupportedFormat, format)
+	}
+}
+
+// writeJSON writes the payload in the envelope of the API.
+func writeJSON(w io.Writer, payload interface{}) error {
+	p, err := json.Marshal(&response.Response{Status: constants.StatusSuccess, Data: payload})
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "%s\n", p)
+
+	return err
+}
+
+// writeICS writes an iCalendar (RFC 5545) with an event per timestamp.
+func writeICS(w io.Writer, list domain.PtList, description *domain.PtDescription) error {
+	lines := []string{"BEGIN:VCALENDAR", "VERSION:2.0", "PRODID:-//ptask//ptask//EN", "CALSCALE:GREGORIAN"}
+	stamp := now().UTC().Format(constants.TimestampLayout)
+
+	for _, timestamp := range list {
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			fmt.Sprintf("UID:%s-%s@ptask", timestamp, description.Period),
+			"DTSTAMP:"+stamp,
+			"DTSTART:"+timestamp,
+			"SUMMARY:"+escapeText(description.Description),
+			"END:VEVENT",
+		)
+	}
+
+	lines = append(lines, "END:VCALENDAR")
+
+	_, err := io.WriteString(w, strings.Join(lines, "\r\n")+"\r\n")
+
+	return err
+}
+
+// escapeText escapes the characters RFC 5545 reserves in TEXT values.
+func escapeText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/KarolosLykos/ptask/internal/admin"
+	"github.com/KarolosLykos/ptask/internal/api"
+	"github.com/KarolosLykos/ptask/internal/config"
+	"github.com/KarolosLykos/ptask/internal/logger"
+	"github.com/KarolosLykos/ptask/internal/ptask/domain"
+	"github.com/KarolosLykos/ptask/internal/ptask/usecase"
+	"github.com/KarolosLykos/ptask/internal/utils"
+)
+
+// reloader applies the config of serve again, e.g. on SIGHUP, without restarting the servers.
+type reloader struct {
+	logger    logger.Logger
+	cfg       *config.Config
+	path      string
+	debug     bool
+	fs        *flag.FlagSet
+	api       *api.API
+	admin     *admin.Server // nil when the admin endpoints are disabled.
+	blackouts *usecase.Blackouts
+}
+
+// reload reads the config file, the environment and the flags of serve again and swaps the settings that
+// can change while serving, logging every change. A config that is invalid is rejected as a whole and the
+// running one is kept.
+func (r *reloader) reload(ctx context.Context) error {
+	running := *r.cfg
+
+	// the flags of fs are bound to r.cfg, so it is loaded in place and restored when rejected.
+	if err := r.cfg.Load(r.path, lookupEnv, r.fs); err != nil {
+		*r.cfg = running
+		return err
+	}
+
+	if r.debug {
+		r.cfg.Logging.Level = "trace"
+	}
+
+	applied, changes, ignored := running.Reload(*r.cfg)
+	*r.cfg = applied
+
+	sets := domain.BlackoutSets{}
+
+	if applied.Blackouts != "" {
+		var err error
+
+		if sets, err = utils.ReadBlackoutSets(applied.Blackouts); err != nil {
+			*r.cfg = running
+			return fmt.Errorf("could not read blackout sets from %s: %w", applied.Blackouts, err)
+		}
+	}
+
+	r.logger.SetFormat(applied.Logging.Format)
+	r.api.SetMaxBodyBytes(applied.Limits.MaxBodyBytes)
+	r.api.SetCORSOrigins(applied.CORS.AllowedOrigins...)
+	r.blackouts.Store(sets)
+
+	// a level set through the admin endpoints is kept until the one of the config changes. Levels are
+	// validated by Load.
+	if applied.Logging.Level != running.Logging.Level {
+		if r.admin != nil {
+			_ = r.admin.SetLevel(applied.Logging.Level)
+		} else {
+			_ = r.logger.SetLevel(applied.Logging.Level)
+		}
+	}
+
+	if r.admin != nil {
+		r.admin.SetToken(applied.Admin.Token)
+		r.admin.SetConfig(applied)
+	}
+
+	for _, change := range changes {
+		r.logger.Info(ctx, "config reloaded: ", change)
+	}
+
+	for _, change := range ignored {
+		r.logger.Warn(ctx, nil, "config change needs a restart: ", change)
+	}
+
+	r.logger.Info(ctx, fmt.Sprintf("config reloaded with %d changes, %d blackout sets", len(changes), len(sets)))
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/ptask/internal/admin"
+	"github.com/KarolosLykos/ptask/internal/api"
+	"github.com/KarolosLykos/ptask/internal/config"
+	"github.com/KarolosLykos/ptask/internal/logger/log"
+	"github.com/KarolosLykos/ptask/internal/ptask/usecase"
+)
+
+func TestReloader_reload(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ptask.yaml")
+	blackouts := filepath.Join(dir, "blackouts.json")
+
+	write := func(name, content string) {
+		require.NoError(t, os.WriteFile(name, []byte(content), 0o600))
+	}
+
+	write(path, "logging:\n  level: info\n")
+
+	out := &bytes.Buffer{}
+	l := logrus.New()
+	l.SetOutput(out)
+	lg := log.New(l)
+
+	cfg := config.Default()
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	cfg.RegisterFlags(fs)
+	require.NoError(t, fs.Parse([]string{"-port", "8081"}))
+	require.NoError(t, cfg.Load(path, lookupEnv, fs))
+
+	b := usecase.NewBlackouts(nil)
+	a := api.New(lg, "127.0.0.1:0", usecase.NewPeriodicTaskUC(lg, usecase.WithBlackouts(b)))
+
+	r := &reloader{logger: lg, cfg: &cfg, path: path, fs: fs, api: a, blackouts: b}
+
+	t.Run("applied", func(t *testing.T) {
+		write(blackouts, `{"holidays":[{"start":"20211224T000000Z","end":"20211227T000000Z"}]}`)
+		write(path, "server:\n  port: \"9000\"\n  read_timeout: 1m\nlogging:\n  level: debug\n  format: text\ncors:\n  allowed_origins: [https://a.example]\nblackouts: "+blackouts+"\n")
+
+		require.NoError(t, r.reload(context.Background()))
+
+		assert.Equal(t, logrus.DebugLevel, l.GetLevel())
+		assert.IsType(t, &logrus.TextFormatter{}, l.Formatter)
+		assert.Contains(t, b.Load(), "holidays")
+		assert.Contains(t, out.String(), `config reloaded: logging.level: \"info\" -> \"debug\"`)
+		assert.Contains(t, out.String(), `config change needs a restart: server.read_timeout: 15s -> 1m0s`)
+
+		// the flag keeps precedence over the file, and timeouts are not reloaded.
+		assert.Equal(t, "8081", cfg.Server.Port)
+		assert.Equal(t, config.Default().Server.ReadTimeout, cfg.Server.ReadTimeout)
+		assert.Equal(t, []string{"https://a.example"}, cfg.CORS.AllowedOrigins)
+
+		req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+		req.Header.Set("Origin", "https://b.example")
+
+		w := httptest.NewRecorder()
+		a.Handler().ServeHTTP(w, req)
+		assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		running := cfg
+
+		write(path, "logging:\n  level: loud\n")
+		assert.ErrorIs(t, r.reload(context.Background()), config.ErrInvalidConfig)
+
+		write(path, "blackouts: "+filepath.Join(dir, "missing.json")+"\n")
+		assert.Error(t, r.reload(context.Background()))
+
+		assert.Equal(t, running, cfg)
+		assert.Equal(t, logrus.DebugLevel, l.GetLevel())
+		assert.Contains(t, b.Load(), "holidays")
+	})
+	t.Run("admin", func(t *testing.T) {
+		r.admin = admin.New(lg, "127.0.0.1:0", "", a)
+		defer func() { r.admin = nil }()
+
+		require.NoError(t, r.admin.SetLevel("trace"))
+
+		write(path, "logging:\n  level: debug\n  format: text\nadmin:\n  token: rotated\n")
+		require.NoError(t, r.reload(context.Background()))
+
+		// the level of the config did not change, the one set through the admin endpoints is kept.
+		assert.Equal(t, logrus.TraceLevel, l.GetLevel())
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/config", nil)
+		req.Header.Set("Authorization", "Bearer rotated")
+
+		w := httptest.NewRecorder()
+		r.admin.Handler().ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "token: REDACTED")
+	})
+}
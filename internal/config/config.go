@@ -0,0 +1,376 @@
+// Package config holds the configuration of the server. Defaults are overridden by a YAML file, then by
+// PTASK_* environment variables, then by flags.
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+
+	"github.com/KarolosLykos/ptask/internal/constants"
+	"github.com/KarolosLykos/ptask/internal/tracing"
+)
+
+// EnvPrefix prefixes the environment variables of the settings, named after their path in the file, e.g.
+// PTASK_SERVER_PORT for server.port.
+const EnvPrefix = "PTASK"
+
+var ErrInvalidConfig = errors.New("invalid config")
+
+type Config struct {
+	Server    Server  `yaml:"server"`
+	Logging   Logging `yaml:"logging"`
+	Limits    Limits  `yaml:"limits"`
+	CORS      CORS    `yaml:"cors"`
+	Tracing   Tracing `yaml:"tracing"`
+	Admin     Admin   `yaml:"admin"`
+	TZData    string  `yaml:"tzdata"`
+	Blackouts string  `yaml:"blackouts"`
+}
+
+// Server holds the listen addresses and the timeouts of the servers. An empty GRPCPort disables gRPC.
+type Server struct {
+	Host              string        `yaml:"host"`
+	Port              string        `yaml:"port"`
+	GRPCPort          string        `yaml:"grpc_port"`
+	ReadTimeout       time.Duration `yaml:"read_timeout"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout"`
+	WriteTimeout      time.Duration `yaml:"write_timeout"`
+	IdleTimeout       time.Duration `yaml:"idle_timeout"`
+	DrainDelay        time.Duration `yaml:"drain_delay"`
+	ShutdownTimeout   time.Duration `yaml:"shutdown_timeout"`
+}
+
+type Logging struct {
+	Level  string `yaml:"level"`
+	Format string `yaml:"format"`
+}
+
+// Limits bound the size of requests. Zero keeps the default of net/http for headers and does not bound bodies.
+type Limits struct {
+	MaxHeaderBytes int   `yaml:"max_header_bytes"`
+	MaxBodyBytes   int64 `yaml:"max_body_bytes"`
+}
+
+// CORS lists the origins allowed to call the API, "*" allowing any.
+type CORS struct {
+	AllowedOrigins []string `yaml:"allowed_origins"`
+}
+
+type Tracing struct {
+	Exporter    string  `yaml:"exporter"`
+	Endpoint    string  `yaml:"endpoint"`
+	SampleRatio float64 `yaml:"sample_ratio"`
+}
+
+// Admin holds the listen address of the admin endpoints and the bearer token they require. An empty Port
+// disables them.
+type Admin struct {
+	Host  string `yaml:"host"`
+	Port  string `yaml:"port"`
+	Token string `yaml:"token"`
+}
+
+// redacted replaces secrets in the config printed, served or logged.
+const redacted = "REDACTED"
+
+// Default returns the configuration the server runs with when nothing overrides it.
+func Default() Config {
+	return Config{
+		Server: Server{
+			Host:              "0.0.0.0",
+			Port:              "8080",
+			GRPCPort:          "9090",
+			ReadTimeout:       15 * time.Second,
+			ReadHeaderTimeout: 5 * time.Second,
+			WriteTimeout:      15 * time.Second,
+			IdleTimeout:       15 * time.Second,
+			DrainDelay:        5 * time.Second,
+			ShutdownTimeout:   30 * time.Second,
+		},
+		Logging: Logging{Level: "info", Format: constants.LoggerFormat},
+		Limits:  Limits{MaxHeaderBytes: 1 << 20, MaxBodyBytes: 1 << 20},
+		CORS:    CORS{AllowedOrigins: []string{"*"}},
+		Tracing: Tracing{Exporter: tracing.ExporterNone, SampleRatio: 1},
+		Admin:   Admin{Host: "127.0.0.1"},
+	}
+}
+
+// RegisterFlags binds the flags of the settings to c.
+func (c *Config) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.Server.Host, "host", c.Server.Host, "-host localhost")
+	fs.StringVar(&c.Server.Port, "port", c.Server.Port, "-port 8080")
+	fs.StringVar(&c.Server.GRPCPort, "grpc-port", c.Server.GRPCPort, "-grpc-port 9090 (empty disables gRPC)")
+	fs.DurationVar(&c.Server.ReadTimeout, "read-timeout", c.Server.ReadTimeout, "-read-timeout 15s")
+	fs.DurationVar(&c.Server.ReadHeaderTimeout, "read-header-timeout", c.Server.ReadHeaderTimeout, "-read-header-timeout 5s")
+	fs.DurationVar(&c.Server.WriteTimeout, "write-timeout", c.Server.WriteTimeout, "-write-timeout 15s")
+	fs.DurationVar(&c.Server.IdleTimeout, "idle-timeout", c.Server.IdleTimeout, "-idle-timeout 15s")
+	fs.DurationVar(&c.Server.DrainDelay, "drain-delay", c.Server.DrainDelay, "-drain-delay 5s (not ready before shutting down)")
+	fs.DurationVar(&c.Server.ShutdownTimeout, "shutdown-timeout", c.Server.ShutdownTimeout, "-shutdown-timeout 30s (grace period of pending requests)")
+	fs.StringVar(&c.Logging.Level, "log-level", c.Logging.Level, "-log-level debug")
+	fs.StringVar(&c.Logging.Format, "log-format", c.Logging.Format, "-log-format json|text")
+	fs.IntVar(&c.Limits.MaxHeaderBytes, "max-header-bytes", c.Limits.MaxHeaderBytes, "-max-header-bytes 1048576")
+	fs.Int64Var(&c.Limits.MaxBodyBytes, "max-body-bytes", c.Limits.MaxBodyBytes, "-max-body-bytes 1048576 (0 does not bound bodies)")
+	fs.Var((*list)(&c.CORS.AllowedOrigins), "cors-origins", "-cors-origins https://a.example,https://b.example")
+	fs.StringVar(&c.Tracing.Exporter, "trace-exporter", c.Tracing.Exporter, "-trace-exporter none|stdout|otlp")
+	fs.StringVar(&c.Tracing.Endpoint, "trace-endpoint", c.Tracing.Endpoint, "-trace-endpoint localhost:4317")
+	fs.Float64Var(&c.Tracing.SampleRatio, "trace-sample-ratio", c.Tracing.SampleRatio, "-trace-sample-ratio 0.1")
+	fs.StringVar(&c.Admin.Host, "admin-host", c.Admin.Host, "-admin-host 127.0.0.1")
+	fs.StringVar(&c.Admin.Port, "admin-port", c.Admin.Port, "-admin-port 8081 (empty disables the admin endpoints)")
+	fs.StringVar(&c.Admin.Token, "admin-token", c.Admin.Token, "-admin-token secret (or "+EnvPrefix+"_ADMIN_TOKEN)")
+	fs.StringVar(&c.TZData, "tzdata", c.TZData, "-tzdata zoneinfo.zip")
+	fs.StringVar(&c.Blackouts, "blackouts", c.Blackouts, "-blackouts blackouts.json")
+}
+
+// Load resets c to the defaults and layers over them the YAML file at path, if any, the environment
+// variables found with lookupEnv and the flags set on fs, whose values are bound to c. The result is
+// validated.
+func (c *Config) Load(path string, lookupEnv func(string) (string, bool), fs *flag.FlagSet) error {
+	set := map[string]string{}
+	fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = f.Value.String()
+	})
+
+	*c = Default()
+
+	if path != "" {
+		if err := c.loadFile(path); err != nil {
+			return err
+		}
+	}
+
+	if err := loadEnv(reflect.ValueOf(c).Elem(), EnvPrefix, lookupEnv); err != nil {
+		return err
+	}
+
+	// the values of the flags were overridden by the defaults.
+	for name, value := range set {
+		if err := fs.Set(name, value); err != nil {
+			return fmt.Errorf("%w: -%s: %v", ErrInvalidConfig, name, err)
+		}
+	}
+
+	return c.Validate()
+}
+
+func (c *Config) loadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("could not read config file: %w", err)
+	}
+	defer f.Close()
+
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+
+	if err := dec.Decode(c); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidConfig, path, err)
+	}
+
+	return nil
+}
+
+// Validate reports every invalid setting of c at once.
+func (c *Config) Validate() error {
+	var problems []string
+
+	invalid := func(setting, format string, args ...interface{}) {
+		problems = append(problems, setting+": "+fmt.Sprintf(format, args...))
+	}
+
+	if !validPort(c.Server.Port) {
+		invalid("server.port", "%q is not a port", c.Server.Port)
+	}
+
+	if c.Server.GRPCPort != "" && !validPort(c.Server.GRPCPort) {
+		invalid("server.grpc_port", "%q is not a port", c.Server.GRPCPort)
+	}
+
+	if c.Server.GRPCPort == c.Server.Port {
+		invalid("server.grpc_port", "same as server.port")
+	}
+
+	for setting, d := range map[string]time.Duration{
+		"server.read_timeout":        c.Server.ReadTimeout,
+		"server.read_header_timeout": c.Server.ReadHeaderTimeout,
+		"server.write_timeout":       c.Server.WriteTimeout,
+		"server.idle_timeout":        c.Server.IdleTimeout,
+		"server.drain_delay":         c.Server.DrainDelay,
+		"server.shutdown_timeout":    c.Server.ShutdownTimeout,
+	} {
+		if d < 0 {
+			invalid(setting, "negative duration %s", d)
+		}
+	}
+
+	if _, err := logrus.ParseLevel(c.Logging.Level); err != nil {
+		invalid("logging.level", "unknown level %q", c.Logging.Level)
+	}
+
+	if f := strings.ToLower(c.Logging.Format); f != "json" && f != "text" {
+		invalid("logging.format", "unknown format %q, want json or text", c.Logging.Format)
+	}
+
+	if c.Limits.MaxHeaderBytes < 0 {
+		invalid("limits.max_header_bytes", "negative size %d", c.Limits.MaxHeaderBytes)
+	}
+
+	if c.Limits.MaxBodyBytes < 0 {
+		invalid("limits.max_body_bytes", "negative size %d", c.Limits.MaxBodyBytes)
+	}
+
+	if len(c.CORS.AllowedOrigins) == 0 {
+		invalid("cors.allowed_origins", "no origin allowed")
+	}
+
+	switch c.Tracing.Exporter {
+	case tracing.ExporterNone, tracing.ExporterStdout, tracing.ExporterOTLP:
+	default:
+		invalid("tracing.exporter", "unknown exporter %q", c.Tracing.Exporter)
+	}
+
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		invalid("tracing.sample_ratio", "%v is not in [0, 1]", c.Tracing.SampleRatio)
+	}
+
+	if c.Admin.Port != "" {
+		if !validPort(c.Admin.Port) {
+			invalid("admin.port", "%q is not a port", c.Admin.Port)
+		}
+
+		if c.Admin.Port == c.Server.Port || c.Admin.Port == c.Server.GRPCPort {
+			invalid("admin.port", "same as server.port or server.grpc_port")
+		}
+
+		if c.Admin.Token == "" {
+			invalid("admin.token", "required by the admin endpoints")
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+
+		return fmt.Errorf("%w: %s", ErrInvalidConfig, strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// Redacted returns c without its secrets.
+func (c Config) Redacted() Config {
+	if c.Admin.Token != "" {
+		c.Admin.Token = redacted
+	}
+
+	return c
+}
+
+// YAML returns c, without its secrets, in the format of the config file.
+func (c *Config) YAML() ([]byte, error) {
+	return yaml.Marshal(c.Redacted())
+}
+
+func validPort(port string) bool {
+	p, err := strconv.Atoi(port)
+
+	return err == nil && p > 0 && p < 1<<16
+}
+
+// loadEnv sets every field of v whose environment variable, named after its path below prefix, is set.
+func loadEnv(v reflect.Value, prefix string, lookupEnv func(string) (string, bool)) error {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		name := prefix + "_" + strings.ToUpper(strings.Split(field.Tag.Get("yaml"), ",")[0])
+
+		if field.Type.Kind() == reflect.Struct {
+			if err := loadEnv(v.Field(i), name, lookupEnv); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		value, ok := lookupEnv(name)
+		if !ok {
+			continue
+		}
+
+		if err := setValue(v.Field(i), value); err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrInvalidConfig, name, err)
+		}
+	}
+
+	return nil
+}
+
+func setValue(v reflect.Value, s string) error {
+	switch v.Interface().(type) {
+	case time.Duration:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(int64(d))
+	case string:
+		v.SetString(s)
+	case int, int64:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(n)
+	case float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+
+		v.SetFloat(f)
+	case []string:
+		v.Set(reflect.ValueOf(split(s)))
+	default:
+		return fmt.Errorf("unsupported setting of type %s", v.Type())
+	}
+
+	return nil
+}
+
+// list is a flag of comma-separated values.
+type list []string
+
+func (l *list) String() string {
+	if l == nil {
+		return ""
+	}
+
+	return strings.Join(*l, ",")
+}
+
+func (l *list) Set(s string) error {
+	*l = split(s)
+
+	return nil
+}
+
+func split(s string) []string {
+	values := make([]string, 0)
+
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
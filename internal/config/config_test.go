@@ -0,0 +1,168 @@
+package config
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const file = `
+server:
+  port: "8081"
+  grpc_port: "9091"
+  read_timeout: 30s
+logging:
+  level: debug
+cors:
+  allowed_origins: [https://a.example]
+`
+
+func TestConfig_Load(t *testing.T) {
+	tt := []struct {
+		name  string
+		file  string
+		env   map[string]string
+		args  []string
+		check func(t *testing.T, c Config)
+		err   string
+	}{
+		{
+			name: "defaults",
+			check: func(t *testing.T, c Config) {
+				assert.Equal(t, Default(), c)
+			},
+		},
+		{
+			name: "file",
+			file: file,
+			check: func(t *testing.T, c Config) {
+				assert.Equal(t, "8081", c.Server.Port)
+				assert.Equal(t, 30*time.Second, c.Server.ReadTimeout)
+				assert.Equal(t, "debug", c.Logging.Level)
+				assert.Equal(t, []string{"https://a.example"}, c.CORS.AllowedOrigins)
+				// settings missing from the file keep their default.
+				assert.Equal(t, "0.0.0.0", c.Server.Host)
+				assert.Equal(t, 15*time.Second, c.Server.WriteTimeout)
+			},
+		},
+		{
+			name: "environment over file",
+			file: file,
+			env: map[string]string{
+				"PTASK_SERVER_PORT":           "8082",
+				"PTASK_SERVER_WRITE_TIMEOUT":  "1m",
+				"PTASK_LIMITS_MAX_BODY_BYTES": "1024",
+				"PTASK_CORS_ALLOWED_ORIGINS":  "https://b.example, https://c.example",
+				"PTASK_TRACING_SAMPLE_RATIO":  "0.5",
+			},
+			check: func(t *testing.T, c Config) {
+				assert.Equal(t, "8082", c.Server.Port)
+				assert.Equal(t, time.Minute, c.Server.WriteTimeout)
+				assert.Equal(t, 30*time.Second, c.Server.ReadTimeout)
+				assert.Equal(t, int64(1024), c.Limits.MaxBodyBytes)
+				assert.Equal(t, []string{"https://b.example", "https://c.example"}, c.CORS.AllowedOrigins)
+				assert.Equal(t, 0.5, c.Tracing.SampleRatio)
+			},
+		},
+		{
+			name: "flags over environment",
+			file: file,
+			env:  map[string]string{"PTASK_SERVER_PORT": "8082", "PTASK_LOGGING_LEVEL": "warn"},
+			args: []string{"-port", "8083", "-cors-origins", "https://d.example"},
+			check: func(t *testing.T, c Config) {
+				assert.Equal(t, "8083", c.Server.Port)
+				assert.Equal(t, "warn", c.Logging.Level)
+				assert.Equal(t, []string{"https://d.example"}, c.CORS.AllowedOrigins)
+			},
+		},
+		{
+			name: "admin",
+			env:  map[string]string{"PTASK_ADMIN_TOKEN": "secret"},
+			args: []string{"-admin-port", "8081"},
+			check: func(t *testing.T, c Config) {
+				assert.Equal(t, Admin{Host: "127.0.0.1", Port: "8081", Token: "secret"}, c.Admin)
+			},
+		},
+		{
+			name: "invalid admin settings",
+			args: []string{"-admin-port", "8080"},
+			err:  "invalid config: admin.port: same as server.port or server.grpc_port; admin.token: required by the admin endpoints",
+		},
+		{name: "unknown setting", file: "server:\n  prot: \"8081\"\n", err: "field prot not found"},
+		{name: "invalid environment variable", env: map[string]string{"PTASK_SERVER_IDLE_TIMEOUT": "soon"}, err: "PTASK_SERVER_IDLE_TIMEOUT"},
+		{
+			name: "invalid settings",
+			args: []string{"-port", "0", "-log-level", "loud", "-trace-sample-ratio", "2", "-read-timeout", "-1s"},
+			err: `invalid config: logging.level: unknown level "loud"; server.port: "0" is not a port; ` +
+				`server.read_timeout: negative duration -1s; tracing.sample_ratio: 2 is not in [0, 1]`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var path string
+
+			if tc.file != "" {
+				path = filepath.Join(t.TempDir(), "ptask.yaml")
+				require.NoError(t, os.WriteFile(path, []byte(tc.file), 0o600))
+			}
+
+			c := Default()
+
+			fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			c.RegisterFlags(fs)
+			require.NoError(t, fs.Parse(tc.args))
+
+			err := c.Load(path, func(name string) (string, bool) {
+				v, ok := tc.env[name]
+				return v, ok
+			}, fs)
+
+			if tc.err != "" {
+				require.ErrorIs(t, err, ErrInvalidConfig)
+				assert.Contains(t, err.Error(), tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			tc.check(t, c)
+		})
+	}
+}
+
+func TestConfig_YAML(t *testing.T) {
+	c := Default()
+
+	b, err := c.YAML()
+	require.NoError(t, err)
+
+	// the printed config is a valid config file.
+	path := filepath.Join(t.TempDir(), "ptask.yaml")
+	require.NoError(t, os.WriteFile(path, b, 0o600))
+
+	loaded := Config{}
+	require.NoError(t, loaded.Load(path, func(string) (string, bool) { return "", false }, flag.NewFlagSet("serve", flag.ContinueOnError)))
+	assert.Equal(t, c, loaded)
+	assert.Contains(t, string(b), "read_timeout: 15s")
+}
+
+func TestConfig_Redacted(t *testing.T) {
+	c := Default()
+	c.Admin.Token = "secret"
+
+	b, err := c.YAML()
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(b), "secret")
+	assert.Equal(t, "REDACTED", c.Redacted().Admin.Token)
+	assert.Equal(t, "secret", c.Admin.Token)
+	assert.Empty(t, Default().Redacted().Admin.Token)
+}
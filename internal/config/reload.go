@@ -0,0 +1,63 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Reload returns the running config c with the settings of next that can change while serving: logging,
+// the bound of request bodies, CORS origins, the admin token and blackout sets. It also describes the
+// changes applied and the ones that need a restart.
+func (c Config) Reload(next Config) (applied Config, changes, ignored []string) {
+	applied = c
+	applied.Logging = next.Logging
+	applied.Limits.MaxBodyBytes = next.Limits.MaxBodyBytes
+	applied.CORS = next.CORS
+	applied.Admin.Token = next.Admin.Token
+	applied.Blackouts = next.Blackouts
+
+	return applied, Diff(c, applied), Diff(applied, next)
+}
+
+// Diff describes every setting that differs between from and to as "path: from -> to", secrets redacted.
+func Diff(from, to Config) []string {
+	changes := diff(reflect.ValueOf(from), reflect.ValueOf(to), "")
+
+	for i, change := range changes {
+		if strings.HasPrefix(change, "admin.token:") {
+			changes[i] = "admin.token: " + redacted + " -> " + redacted
+		}
+	}
+
+	return changes
+}
+
+func diff(from, to reflect.Value, prefix string) []string {
+	var changes []string
+
+	for i := 0; i < from.NumField(); i++ {
+		path := prefix + strings.Split(from.Type().Field(i).Tag.Get("yaml"), ",")[0]
+
+		if from.Field(i).Kind() == reflect.Struct {
+			changes = append(changes, diff(from.Field(i), to.Field(i), path+".")...)
+			continue
+		}
+
+		f, t := from.Field(i).Interface(), to.Field(i).Interface()
+		if !reflect.DeepEqual(f, t) {
+			changes = append(changes, fmt.Sprintf("%s: %s -> %s", path, format(f), format(t)))
+		}
+	}
+
+	return changes
+}
+
+func format(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return strconv.Quote(s)
+	}
+
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Reload(t *testing.T) {
+	running := Default()
+
+	next := Default()
+	next.Server.Port = "8081"
+	next.Server.ReadTimeout = time.Minute
+	next.Logging.Level = "debug"
+	next.Limits.MaxHeaderBytes = 1024
+	next.Limits.MaxBodyBytes = 2048
+	next.CORS.AllowedOrigins = []string{"https://a.example"}
+	next.Admin.Port = "8082"
+	next.Admin.Token = "secret"
+
+	applied, changes, ignored := running.Reload(next)
+
+	assert.Equal(t, "8080", applied.Server.Port)
+	assert.Equal(t, "debug", applied.Logging.Level)
+	assert.Equal(t, int64(2048), applied.Limits.MaxBodyBytes)
+	assert.Equal(t, 1<<20, applied.Limits.MaxHeaderBytes)
+
+	assert.Equal(t, []string{
+		`logging.level: "info" -> "debug"`,
+		`limits.max_body_bytes: 1048576 -> 2048`,
+		`cors.allowed_origins: [*] -> [https://a.example]`,
+		`admin.token: REDACTED -> REDACTED`,
+	}, changes)
+	assert.Equal(t, []string{
+		`server.port: "8080" -> "8081"`,
+		`server.read_timeout: 15s -> 1m0s`,
+		`limits.max_header_bytes: 1048576 -> 1024`,
+		`admin.port: "" -> "8082"`,
+	}, ignored)
+
+	_, changes, ignored = running.Reload(running)
+	assert.Empty(t, changes)
+	assert.Empty(t, ignored)
+}
@@ -1,12 +1,40 @@
 package constants
 
+import "github.com/KarolosLykos/ptask/pkg/schedule"
+
 const (
 	LoggerFormat    = "json"
 	TimestampLayout = "20060102T150405Z"
-	Year            = "y"
-	Month           = "mo"
-	Day             = "d"
-	Hour            = "h"
+	Year            = schedule.Year
+	Month           = schedule.Month
+	Day             = schedule.Day
+	Hour            = schedule.Hour
 	StatusSuccess   = "success"
 	StatusError     = "error"
+	Union           = "union"
+	Intersect       = "intersect"
+	Except          = "except"
+	MaxExprDepth    = 16
+	MaxSchedules    = 1000
+	LangEnglish     = "en"
+	LangGreek       = "el"
+	LangGerman      = "de"
+	PreviewCount    = 5
+	MaxPreviewCount = 100
+	MaxDisplayZones = 10
+
+	GraphQLDefaultFirst = 100
+	MaxGraphQLFirst     = 1000
+	MaxGraphQLDepth     = 8
+	MaxGraphQLCost      = 10000
+
+	TzdataVersionHeader = "X-Tzdata-Version"
+	RequestIDHeader     = "X-Request-ID"
+	TraceParentHeader   = "traceparent"
+	DeprecationHeader   = "Deprecation"
+	SunsetHeader        = "Sunset"
+	LinkHeader          = "Link"
+
+	ProblemContentType = "application/problem+json"
+	ProblemTypePrefix  = "urn:ptask:problem:"
 )
@@ -0,0 +1,83 @@
+// Package correlation identifies the requests the service handles with an X-Request-ID and a W3C trace context,
+// so every log line of a request can be correlated with the others and with the logs of its callers.
+package correlation
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/KarolosLykos/ptask/internal/logger"
+)
+
+// MaxRequestIDLength is the length over which a request ID sent by a client is replaced.
+const MaxRequestIDLength = 128
+
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:/+=-]+$`)
+
+// IDs identify a request. TraceParent is the traceparent of the span of the service, a child of the one of
+// the caller when it sent one.
+type IDs struct {
+	RequestID   string
+	TraceID     string
+	SpanID      string
+	TraceParent string
+}
+
+type idsKey struct{}
+
+// New returns the IDs of a request from the X-Request-ID it was sent with, generating it when it is missing
+// or invalid, and from the span of the service. When spans are not recorded, span is the one of the caller,
+// if any, whose trace is kept with a new span.
+func New(requestID string, span trace.SpanContext) IDs {
+	ids := IDs{RequestID: strings.TrimSpace(requestID)}
+
+	if len(ids.RequestID) > MaxRequestIDLength || !requestIDPattern.MatchString(ids.RequestID) {
+		ids.RequestID = random(16)
+	}
+
+	flags := "01"
+
+	switch {
+	case span.IsValid() && !span.IsRemote():
+		ids.TraceID, ids.SpanID, flags = span.TraceID().String(), span.SpanID().String(), span.TraceFlags().String()
+	case span.IsValid():
+		ids.TraceID, ids.SpanID, flags = span.TraceID().String(), random(8), span.TraceFlags().String()
+	default:
+		ids.TraceID, ids.SpanID = random(16), random(8)
+	}
+
+	ids.TraceParent = fmt.Sprintf("00-%s-%s-%s", ids.TraceID, ids.SpanID, flags)
+
+	return ids
+}
+
+// WithIDs returns a copy of ctx carrying ids, which are also logged with every entry logged with ctx.
+func WithIDs(ctx context.Context, ids IDs) context.Context {
+	ctx = context.WithValue(ctx, idsKey{}, ids)
+
+	return logger.WithFields(ctx, logger.Fields{
+		"request_id": ids.RequestID,
+		"trace_id":   ids.TraceID,
+		"span_id":    ids.SpanID,
+	})
+}
+
+// FromContext returns the IDs ctx carries, if any.
+func FromContext(ctx context.Context) (IDs, bool) {
+	ids, ok := ctx.Value(idsKey{}).(IDs)
+
+	return ids, ok
+}
+
+func random(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,91 @@
+package correlation
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/KarolosLykos/ptask/internal/logger"
+)
+
+func TestNew(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+
+	tt := []struct {
+		name        string
+		requestID   string
+		span        trace.SpanContext
+		keepRequest bool
+		keepSpan    bool
+		traceID     string
+		flags       string
+	}{
+		{name: "generated", flags: "01"},
+		{
+			name:        "span of the service",
+			requestID:   "7b4c2c1e-0d5f-4f3e-9b1a-2f6f0c3d4e5a",
+			span:        trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID, TraceFlags: trace.FlagsSampled}),
+			keepRequest: true,
+			keepSpan:    true,
+			traceID:     "4bf92f3577b34da6a3ce929d0e0e4736",
+			flags:       "01",
+		},
+		{
+			name:    "span of the caller",
+			span:    trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID, Remote: true}),
+			traceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+			flags:   "00",
+		},
+		{name: "request ID too long", requestID: strings.Repeat("a", MaxRequestIDLength+1), flags: "01"},
+		{name: "request ID with spaces", requestID: "a b", flags: "01"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ids := New(tc.requestID, tc.span)
+
+			if tc.keepRequest {
+				assert.Equal(t, tc.requestID, ids.RequestID)
+			} else {
+				assert.Regexp(t, `^[0-9a-f]{32}$`, ids.RequestID)
+			}
+
+			if tc.traceID != "" {
+				assert.Equal(t, tc.traceID, ids.TraceID)
+			} else {
+				assert.Regexp(t, `^[0-9a-f]{32}$`, ids.TraceID)
+			}
+
+			// the span of the caller is never reused.
+			if tc.keepSpan {
+				assert.Equal(t, "00f067aa0ba902b7", ids.SpanID)
+			} else {
+				assert.Regexp(t, `^[0-9a-f]{16}$`, ids.SpanID)
+				assert.NotEqual(t, "00f067aa0ba902b7", ids.SpanID)
+			}
+
+			assert.Equal(t, "00-"+ids.TraceID+"-"+ids.SpanID+"-"+tc.flags, ids.TraceParent)
+		})
+	}
+}
+
+func TestWithIDs(t *testing.T) {
+	ctx := logger.WithFields(context.Background(), logger.Fields{"job": "sync"})
+
+	ids := New("req-1", trace.SpanContext{})
+	ctx = WithIDs(ctx, ids)
+
+	got, ok := FromContext(ctx)
+	require.True(t, ok)
+	assert.Equal(t, ids, got)
+
+	assert.Equal(t, logger.Fields{"job": "sync", "request_id": "req-1", "trace_id": ids.TraceID, "span_id": ids.SpanID}, logger.FieldsFrom(ctx))
+
+	_, ok = FromContext(context.Background())
+	assert.False(t, ok)
+}
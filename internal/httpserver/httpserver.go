@@ -0,0 +1,98 @@
+// Package httpserver runs the http.Server of a handler: it listens on an address, serves in the background
+// until it is shut down and reports the error serving fails with once started.
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/KarolosLykos/ptask/internal/logger"
+)
+
+// Timeouts bound the time the server spends reading a request and its header, writing a response and
+// keeping an idle connection open.
+type Timeouts struct {
+	Read       time.Duration
+	ReadHeader time.Duration
+	Write      time.Duration
+	Idle       time.Duration
+}
+
+// DefaultTimeouts are the timeouts of the servers whose timeouts are not configured.
+var DefaultTimeouts = Timeouts{
+	Read:       15 * time.Second,
+	ReadHeader: 5 * time.Second,
+	Write:      15 * time.Second,
+	Idle:       15 * time.Second,
+}
+
+// Server serves a handler on an address once started. Its name tells it apart in logs and errors.
+type Server struct {
+	logger logger.Logger
+	name   string
+	addr   string
+	server *http.Server
+	errs   chan error
+}
+
+// New returns the server of handler, bounded by timeouts and, unless it is 0, by maxHeaderBytes.
+func New(logger logger.Logger, name, addr string, handler http.Handler, timeouts Timeouts, maxHeaderBytes int) *Server {
+	return &Server{
+		logger: logger,
+		name:   name,
+		addr:   addr,
+		server: &http.Server{
+			Handler:           handler,
+			ReadTimeout:       timeouts.Read,
+			ReadHeaderTimeout: timeouts.ReadHeader,
+			WriteTimeout:      timeouts.Write,
+			IdleTimeout:       timeouts.Idle,
+			MaxHeaderBytes:    maxHeaderBytes,
+		},
+		errs: make(chan error, 1),
+	}
+}
+
+// Start listens on the address of the server and serves in the background. Failing to listen is returned,
+// failing to serve afterwards is sent on Err.
+func (s *Server) Start(ctx context.Context) error {
+	s.logger.Info(ctx, "starting ", s.name, " server...")
+
+	lis, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("could not listen on %s: %w", s.addr, err)
+	}
+
+	go func() {
+		if err := s.server.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.errs <- fmt.Errorf("%s server on %s failed: %w", s.name, s.addr, err)
+		}
+	}()
+
+	s.logger.Info(ctx, s.name, " server started on: ", lis.Addr().String())
+
+	return nil
+}
+
+// Err receives the error the server fails with after it started.
+func (s *Server) Err() <-chan error {
+	return s.errs
+}
+
+// Shutdown stops accepting requests and waits for the pending ones, unless ctx is done first, in which case
+// the remaining connections are closed and the error of ctx is returned.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.logger.Debug(ctx, "shutting down ", s.name, " server...")
+
+	if err := s.server.Shutdown(ctx); err != nil {
+		_ = s.server.Close()
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package httpserver
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/ptask/internal/logger"
+	"github.com/KarolosLykos/ptask/internal/logger/log"
+)
+
+func TestServer_Start(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer lis.Close()
+
+	// the address is in use.
+	s := New(getLogger(), "test", lis.Addr().String(), http.NotFoundHandler(), DefaultTimeouts, 0)
+	assert.Error(t, s.Start(context.Background()))
+	assert.NoError(t, s.Shutdown(context.Background()))
+}
+
+func TestServer_Shutdown(t *testing.T) {
+	addr := freeAddr(t)
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+
+	s := New(getLogger(), "test", addr, ok, Timeouts{Read: time.Second, ReadHeader: time.Second, Write: time.Second, Idle: time.Second}, 0)
+	require.NoError(t, s.Start(context.Background()))
+
+	res, err := http.Get("http://" + addr + "/")
+	require.NoError(t, err)
+	_ = res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, time.Second, s.server.ReadHeaderTimeout)
+
+	// a connection that never completes its request keeps the server busy until the grace period ends.
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("GET / HTTP/1.1\r\n"))
+	require.NoError(t, err)
+
+	time.Sleep(50 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+
+	assert.ErrorIs(t, s.Shutdown(ctx), context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+
+	select {
+	case err := <-s.Err():
+		t.Fatalf("unexpected server error: %v", err)
+	default:
+	}
+}
+
+func freeAddr(t *testing.T) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer lis.Close()
+
+	return lis.Addr().String()
+}
+
+func getLogger() logger.Logger {
+	l := &logrus.Logger{
+		Out:          io.Discard,
+		Hooks:        make(logrus.LevelHooks),
+		ReportCaller: false,
+		ExitFunc:     os.Exit,
+		Level:        logrus.DebugLevel,
+		Formatter:    &logrus.JSONFormatter{},
+	}
+
+	return log.New(l)
+}
@@ -58,13 +58,23 @@ func setLevel(lvl string) logrus.Level {
 	return level
 }
 
-func (l *logruslog) SetLevel(lvl string) {
+func (l *logruslog) SetLevel(lvl string) error {
 	level, err := logrus.ParseLevel(lvl)
 	if err != nil {
-		l.logger.SetLevel(logrus.InfoLevel)
+		return err
 	}
 
 	l.logger.SetLevel(level)
+
+	return nil
+}
+
+func (l *logruslog) Level() string {
+	return l.logger.GetLevel().String()
+}
+
+func (l *logruslog) SetFormat(format string) {
+	l.logger.SetFormatter(setFormatter(format))
 }
 
 func (l *logruslog) Trace(ctx context.Context, msg ...interface{}) {
@@ -99,8 +109,15 @@ func (l *logruslog) Panic(ctx context.Context, err error, msg ...interface{}) {
 	le.Panic(msg...)
 }
 
-func (l *logruslog) parseMessages(_ context.Context, err error) *logrus.Entry {
-	e := l.logger.WithFields(logrus.Fields{"service": "ptask"})
+func (l *logruslog) parseMessages(ctx context.Context, err error) *logrus.Entry {
+	fields := logrus.Fields{"service": "ptask"}
+
+	// correlate the entries of a request, or of anything else scoped by ctx.
+	for k, v := range logger.FieldsFrom(ctx) {
+		fields[k] = v
+	}
+
+	e := l.logger.WithFields(fields)
 
 	if err != nil {
 		e = e.WithField("err", err.Error())
@@ -0,0 +1,55 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/ptask/internal/logger"
+)
+
+func TestLogruslog_ContextFields(t *testing.T) {
+	out := &bytes.Buffer{}
+
+	l := New(&logrus.Logger{
+		Out:       out,
+		Hooks:     make(logrus.LevelHooks),
+		ExitFunc:  os.Exit,
+		Level:     logrus.InfoLevel,
+		Formatter: &logrus.JSONFormatter{},
+	})
+
+	ctx := logger.WithFields(context.Background(), logger.Fields{"request_id": "req-1"})
+
+	l.Info(ctx, "listing")
+	l.Error(ctx, errors.New("boom"), "could not list")
+
+	dec := json.NewDecoder(out)
+
+	for _, msg := range []string{"listing", "could not list"} {
+		entry := map[string]interface{}{}
+		require.NoError(t, dec.Decode(&entry))
+
+		assert.Equal(t, msg, entry["msg"])
+		assert.Equal(t, "req-1", entry["request_id"])
+		assert.Equal(t, "ptask", entry["service"])
+	}
+}
+
+func TestLogruslog_SetLevel(t *testing.T) {
+	l := New(&logrus.Logger{Out: &bytes.Buffer{}, Level: logrus.InfoLevel, Formatter: &logrus.JSONFormatter{}})
+
+	require.NoError(t, l.SetLevel("trace"))
+	assert.Equal(t, "trace", l.Level())
+
+	// an unknown level keeps the one the logger had.
+	assert.Error(t, l.SetLevel("loud"))
+	assert.Equal(t, "trace", l.Level())
+}
@@ -5,7 +5,10 @@ import (
 )
 
 type Logger interface {
-	SetLevel(lvl string)
+	// SetLevel sets the level of the logger, or returns the parse error and keeps the level it had.
+	SetLevel(lvl string) error
+	Level() string
+	SetFormat(format string)
 	Trace(ctx context.Context, msg ...interface{})
 	Debug(ctx context.Context, msg ...interface{})
 	Info(ctx context.Context, msg ...interface{})
@@ -13,3 +16,34 @@ type Logger interface {
 	Error(ctx context.Context, err error, msg ...interface{})
 	Panic(ctx context.Context, err error, msg ...interface{})
 }
+
+// Fields are context-scoped fields, carried by every entry logged with the context.
+type Fields map[string]interface{}
+
+type fieldsKey struct{}
+
+// WithFields returns a copy of ctx carrying fields along with the ones ctx already carries.
+func WithFields(ctx context.Context, fields Fields) context.Context {
+	merged := Fields{}
+
+	for k, v := range FieldsFrom(ctx) {
+		merged[k] = v
+	}
+
+	for k, v := range fields {
+		merged[k] = v
+	}
+
+	return context.WithValue(ctx, fieldsKey{}, merged)
+}
+
+// FieldsFrom returns the fields ctx carries.
+func FieldsFrom(ctx context.Context) Fields {
+	if ctx == nil {
+		return nil
+	}
+
+	fields, _ := ctx.Value(fieldsKey{}).(Fields)
+
+	return fields
+}
@@ -0,0 +1,165 @@
+// Package metrics measures the requests the API serves and exposes the measurements in the Prometheus text
+// format.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/KarolosLykos/ptask/internal/utils/httperrors"
+)
+
+const namespace = "ptask"
+
+// unmatched labels the requests no route matched, so unknown paths cannot grow the number of series.
+const unmatched = "unmatched"
+
+// Metrics holds the collectors of an API. Each Metrics has its own registry, so several APIs, e.g. in
+// tests, never collide.
+type Metrics struct {
+	registry    *prometheus.Registry
+	requests    *prometheus.CounterVec
+	duration    *prometheus.HistogramVec
+	occurrences *prometheus.HistogramVec
+	errors      *prometheus.CounterVec
+	inFlight    prometheus.Gauge
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "Number of HTTP requests served, by route, method and status.",
+		}, []string{"route", "method", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of the HTTP requests, by route and method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method"}),
+		occurrences: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "response_occurrences",
+			Help:      "Number of occurrences per response, by route.",
+			Buckets:   prometheus.ExponentialBuckets(1, 4, 8),
+		}, []string{"route"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "errors_total",
+			Help:      "Number of error responses, by route and kind of error.",
+		}, []string{"route", "code"}),
+		inFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_in_flight",
+			Help:      "Number of HTTP requests being served.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		m.requests,
+		m.duration,
+		m.occurrences,
+		m.errors,
+		m.inFlight,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	return m
+}
+
+// Registry returns the registry of the collectors, e.g. to register the ones of other subsystems.
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+// Handler serves the metrics in the Prometheus text format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// Middleware measures the requests served by a router, labelled with the template of the route they
+// matched rather than their path.
+func (m *Metrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := routeOf(r)
+		rec := &recorder{ResponseWriter: w, metrics: m, route: route, status: http.StatusOK}
+
+		m.inFlight.Inc()
+		defer m.inFlight.Dec()
+
+		start := time.Now()
+		defer func() {
+			m.duration.WithLabelValues(route, r.Method).Observe(time.Since(start).Seconds())
+			m.requests.WithLabelValues(route, r.Method, strconv.Itoa(rec.status)).Inc()
+		}()
+
+		next.ServeHTTP(rec, r)
+	})
+}
+
+func routeOf(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			return template
+		}
+	}
+
+	return unmatched
+}
+
+// recorder captures the status of a response and observes what the handlers report about it.
+type recorder struct {
+	http.ResponseWriter
+	metrics     *Metrics
+	route       string
+	status      int
+	wroteHeader bool
+}
+
+func (rec *recorder) WriteHeader(status int) {
+	if !rec.wroteHeader {
+		rec.status, rec.wroteHeader = status, true
+	}
+
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *recorder) Write(b []byte) (int, error) {
+	rec.wroteHeader = true
+
+	return rec.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the wrapped ResponseWriter.
+func (rec *recorder) Unwrap() http.ResponseWriter {
+	return rec.ResponseWriter
+}
+
+// Flush lets streamed responses, e.g. the ones of the swagger UI, through.
+func (rec *recorder) Flush() {
+	if f, ok := rec.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (rec *recorder) ObserveError(e *httperrors.Error) {
+	rec.metrics.errors.WithLabelValues(rec.route, e.Code).Inc()
+}
+
+func (rec *recorder) ObserveOccurrences(n int) {
+	rec.metrics.occurrences.WithLabelValues(rec.route).Observe(float64(n))
+}
@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/ptask/internal/utils/httperrors"
+	"github.com/KarolosLykos/ptask/internal/utils/response"
+)
+
+func TestMetrics(t *testing.T) {
+	m := New()
+
+	router := mux.NewRouter()
+	router.Use(m.Middleware)
+
+	router.HandleFunc("/v1/ptlist", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("period") == "" {
+			response.Error(w, httperrors.ErrInvalidPeriod)
+			return
+		}
+
+		response.ObserveOccurrences(w, 3)
+		response.Success(w, http.StatusOK, []string{"a", "b", "c"})
+	}).Methods(http.MethodGet)
+
+	// problem details writers are looked through.
+	v2 := router.PathPrefix("/v2").Subrouter()
+	v2.Use(response.Problems)
+	v2.HandleFunc("/ptlist", func(w http.ResponseWriter, r *http.Request) {
+		response.Error(w, httperrors.Wrapf(httperrors.ErrInvalidTimezone, "unknown time zone"))
+	}).Methods(http.MethodGet)
+
+	router.Handle("/metrics", m.Handler()).Methods(http.MethodGet)
+
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	for _, path := range []string{"/v1/ptlist?period=1h", "/v1/ptlist?period=1h", "/v1/ptlist", "/v2/ptlist?tz=x"} {
+		res, err := http.Get(srv.URL + path)
+		require.NoError(t, err)
+		_ = res.Body.Close()
+	}
+
+	res, err := http.Get(srv.URL + "/metrics")
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	b, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+
+	body := string(b)
+
+	for _, line := range []string{
+		`ptask_http_requests_total{method="GET",route="/v1/ptlist",status="200"} 2`,
+		`ptask_http_requests_total{method="GET",route="/v1/ptlist",status="400"} 1`,
+		`ptask_http_requests_total{method="GET",route="/v2/ptlist",status="400"} 1`,
+		`ptask_http_request_duration_seconds_count{method="GET",route="/v1/ptlist"} 3`,
+		`ptask_http_response_occurrences_sum{route="/v1/ptlist"} 6`,
+		`ptask_http_response_occurrences_count{route="/v1/ptlist"} 2`,
+		`ptask_http_errors_total{code="invalid_period",route="/v1/ptlist"} 1`,
+		`ptask_http_errors_total{code="invalid_timezone",route="/v2/ptlist"} 1`,
+		// the scrape itself is in flight.
+		`ptask_http_requests_in_flight 1`,
+	} {
+		assert.Contains(t, body, line)
+	}
+}
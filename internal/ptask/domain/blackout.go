@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"time"
+)
+
+// Blackout is a half-open [Start, End) interval during which occurrences are suppressed.
+type Blackout struct {
+	Start time.Time
+	End   time.Time
+}
+
+// BlackoutSets holds named lists of blackout intervals, e.g. release freezes.
+type BlackoutSets map[string][]Blackout
+
+// PtSchedule is a list of matching timestamps along with the ones suppressed by blackouts.
+type PtSchedule struct {
+	List        PtList `json:"list"`
+	Suppressed  PtList `json:"suppressed"`
+	Description string `json:"description,omitempty"`
+}
+
+// Contains reports whether t falls inside the blackout interval.
+func (b Blackout) Contains(t time.Time) bool {
+	return !t.Before(b.Start) && t.Before(b.End)
+}
+
+// InBlackout reports whether t falls inside any of the given blackout intervals.
+func InBlackout(t time.Time, blackouts []Blackout) bool {
+	for _, b := range blackouts {
+		if b.Contains(t) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInBlackout(t *testing.T) {
+	blackouts := []Blackout{
+		{Start: time.Date(2021, 7, 29, 0, 0, 0, 0, time.UTC), End: time.Date(2021, 7, 30, 0, 0, 0, 0, time.UTC)},
+		{Start: time.Date(2021, 8, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2021, 8, 2, 0, 0, 0, 0, time.UTC)},
+	}
+
+	tt := []struct {
+		name     string
+		point    time.Time
+		expected bool
+	}{
+		{name: "before", point: time.Date(2021, 7, 28, 23, 59, 59, 0, time.UTC), expected: false},
+		{name: "start is inclusive", point: time.Date(2021, 7, 29, 0, 0, 0, 0, time.UTC), expected: true},
+		{name: "inside", point: time.Date(2021, 7, 29, 12, 0, 0, 0, time.UTC), expected: true},
+		{name: "end is exclusive", point: time.Date(2021, 7, 30, 0, 0, 0, 0, time.UTC), expected: false},
+		{name: "second interval", point: time.Date(2021, 8, 1, 21, 0, 0, 0, time.UTC), expected: true},
+		{name: "other timezone", point: time.Date(2021, 8, 1, 23, 0, 0, 0, time.FixedZone("EEST", 3*60*60)), expected: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, InBlackout(tc.point, blackouts))
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"sort"
+	"time"
+
+	"github.com/KarolosLykos/ptask/internal/constants"
+)
+
+// NamedIterator is the iterator of a named schedule taking part in a collision analysis.
+type NamedIterator struct {
+	Name string
+	It   Iterator
+}
+
+// Collision is an instant where more than the threshold of tasks fire together.
+type Collision struct {
+	Point string   `json:"point"`
+	Tasks []string `json:"tasks"`
+}
+
+// CollisionReport summarizes how many tasks fire at the same instants of a window.
+type CollisionReport struct {
+	Collisions []Collision       `json:"collisions"`
+	Peak       int               `json:"peak"`
+	PeakPoints PtList            `json:"peak_points"`
+	Histogram  map[int]int       `json:"histogram"`
+	Offsets    map[string]string `json:"offsets"`
+}
+
+// AnalyzeCollisions merges the schedules and reports the instants where more than threshold tasks coincide,
+// the peak concurrency, a histogram of instants per concurrency level and offsets that reduce the collisions of
+// the colliding tasks.
+func AnalyzeCollisions(schedules []NamedIterator, threshold int) (*CollisionReport, error) {
+	report := &CollisionReport{
+		Collisions: []Collision{},
+		PeakPoints: PtList{},
+		Histogram:  map[int]int{},
+		Offsets:    map[string]string{},
+	}
+
+	peekers := make([]*peeker, len(schedules))
+	for i, s := range schedules {
+		peekers[i] = &peeker{it: s.It}
+	}
+
+	colliding := map[string]bool{}
+
+	for {
+		var (
+			min   time.Time
+			found bool
+		)
+
+		for _, p := range peekers {
+			head, ok, err := p.peek()
+			if err != nil {
+				return nil, err
+			}
+
+			if ok && (!found || head.Before(min)) {
+				min, found = head, true
+			}
+		}
+
+		if !found {
+			break
+		}
+
+		tasks := []string{}
+
+		for i, p := range peekers {
+			if head, ok, _ := p.peek(); ok && head.Equal(min) {
+				tasks = append(tasks, schedules[i].Name)
+				p.pop()
+			}
+		}
+
+		point := min.UTC().Format(constants.TimestampLayout)
+		report.Histogram[len(tasks)]++
+
+		switch {
+		case len(tasks) > report.Peak:
+			report.Peak = len(tasks)
+			report.PeakPoints = PtList{point}
+		case len(tasks) == report.Peak:
+			report.PeakPoints = append(report.PeakPoints, point)
+		}
+
+		if len(tasks) > threshold {
+			report.Collisions = append(report.Collisions, Collision{Point: point, Tasks: tasks})
+
+			for _, task := range tasks {
+				colliding[task] = true
+			}
+		}
+	}
+
+	report.Offsets = suggestOffsets(colliding)
+
+	return report, nil
+}
+
+// suggestOffsets staggers the colliding tasks evenly within an hour, which reduces their collisions. It
+// does not rule them out: zones offset by half or three quarters of an hour, DST shifts and a shifted task
+// landing on the hour of one left unshifted can still make tasks fire at the same instant.
+func suggestOffsets(colliding map[string]bool) map[string]string {
+	names := make([]string, 0, len(colliding))
+	for name := range colliding {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	offsets := make(map[string]string, len(names))
+	for i, name := range names {
+		offset := (time.Duration(i) * time.Hour / time.Duration(len(names))).Truncate(time.Second)
+		offsets[name] = offset.String()
+	}
+
+	return offsets
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeCollisions(t *testing.T) {
+	schedules := []NamedIterator{
+		{Name: "sync", It: hours(0, 1, 2, 3, 4, 5, 6)},
+		{Name: "backup", It: hours(0, 6)},
+		{Name: "report", It: hours(0, 3, 6)},
+		{Name: "cleanup", It: hours(5)},
+	}
+
+	report, err := AnalyzeCollisions(schedules, 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, report.Peak)
+	assert.Equal(t, PtList{"20210715T000000Z", "20210715T060000Z"}, report.PeakPoints)
+	assert.Equal(t, map[int]int{1: 3, 2: 2, 3: 2}, report.Histogram)
+	assert.Equal(t, []Collision{
+		{Point: "20210715T000000Z", Tasks: []string{"sync", "backup", "report"}},
+		{Point: "20210715T030000Z", Tasks: []string{"sync", "report"}},
+		{Point: "20210715T050000Z", Tasks: []string{"sync", "cleanup"}},
+		{Point: "20210715T060000Z", Tasks: []string{"sync", "backup", "report"}},
+	}, report.Collisions)
+	assert.Equal(t, map[string]string{"backup": "0s", "cleanup": "15m0s", "report": "30m0s", "sync": "45m0s"}, report.Offsets)
+
+	report, err = AnalyzeCollisions([]NamedIterator{{Name: "sync", It: hours(0, 1)}, {Name: "backup", It: hours(0)}}, 2)
+	require.NoError(t, err)
+
+	assert.Empty(t, report.Collisions)
+	assert.Empty(t, report.Offsets)
+	assert.Equal(t, 2, report.Peak)
+}
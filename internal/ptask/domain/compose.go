@@ -0,0 +1,214 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/KarolosLykos/ptask/internal/utils/httperrors"
+)
+
+// Expression is a node of a schedule expression tree. Leaves carry a Period,
+// inner nodes an operator (union, intersect or except) applied to their Args.
+type Expression struct {
+	Op     string
+	Period *Period
+	Args   []*Expression
+}
+
+// Iterator lazily yields invocation points in ascending order.
+type Iterator interface {
+	Next() (time.Time, bool, error)
+}
+
+type taskIterator struct {
+	task  *PeriodicTask
+	point time.Time
+	end   time.Time
+}
+
+// NewTaskIterator iterates over the invocations of a periodic task that are before end.
+func NewTaskIterator(task *PeriodicTask, end time.Time) Iterator {
+	return &taskIterator{task: task, point: task.InvocationPoint, end: end}
+}
+
+func (t *taskIterator) Next() (time.Time, bool, error) {
+	if !t.point.Before(t.end) {
+		return time.Time{}, false, nil
+	}
+
+	point := t.point
+
+	next, err := t.task.Next(point)
+	if err != nil {
+		return time.Time{}, false, httperrors.WrapPeriod(err)
+	}
+
+	t.point = next
+
+	return point, true, nil
+}
+
+// peeker buffers the head of an iterator so operators can compare heads without consuming them.
+type peeker struct {
+	it    Iterator
+	head  time.Time
+	ok    bool
+	ready bool
+}
+
+func (p *peeker) peek() (time.Time, bool, error) {
+	if !p.ready {
+		head, ok, err := p.it.Next()
+		if err != nil {
+			return time.Time{}, false, err
+		}
+
+		p.head, p.ok, p.ready = head, ok, true
+	}
+
+	return p.head, p.ok, nil
+}
+
+func (p *peeker) pop() {
+	p.ready = false
+}
+
+func newPeekers(its []Iterator) []*peeker {
+	peekers := make([]*peeker, len(its))
+	for i, it := range its {
+		peekers[i] = &peeker{it: it}
+	}
+
+	return peekers
+}
+
+type unionIterator struct {
+	its []*peeker
+}
+
+// Union yields the points of any of the iterators, with duplicates removed.
+func Union(its ...Iterator) Iterator {
+	return &unionIterator{its: newPeekers(its)}
+}
+
+func (u *unionIterator) Next() (time.Time, bool, error) {
+	var (
+		min   time.Time
+		found bool
+	)
+
+	for _, it := range u.its {
+		head, ok, err := it.peek()
+		if err != nil {
+			return time.Time{}, false, err
+		}
+
+		if ok && (!found || head.Before(min)) {
+			min, found = head, true
+		}
+	}
+
+	if !found {
+		return time.Time{}, false, nil
+	}
+
+	for _, it := range u.its {
+		if head, ok, _ := it.peek(); ok && head.Equal(min) {
+			it.pop()
+		}
+	}
+
+	return min, true, nil
+}
+
+type intersectIterator struct {
+	its []*peeker
+}
+
+// Intersect yields the points common to all the iterators.
+func Intersect(its ...Iterator) Iterator {
+	return &intersectIterator{its: newPeekers(its)}
+}
+
+func (n *intersectIterator) Next() (time.Time, bool, error) {
+	if len(n.its) == 0 {
+		return time.Time{}, false, nil
+	}
+
+	for {
+		var max time.Time
+
+		for _, it := range n.its {
+			head, ok, err := it.peek()
+			if err != nil || !ok {
+				return time.Time{}, false, err
+			}
+
+			if head.After(max) {
+				max = head
+			}
+		}
+
+		matched := true
+
+		for _, it := range n.its {
+			head, _, _ := it.peek()
+			if head.Before(max) {
+				it.pop()
+
+				matched = false
+			}
+		}
+
+		if matched {
+			for _, it := range n.its {
+				it.pop()
+			}
+
+			return max, true, nil
+		}
+	}
+}
+
+type exceptIterator struct {
+	base    *peeker
+	exclude *peeker
+}
+
+// Except yields the points of base that are not yielded by any of the excluded iterators.
+func Except(base Iterator, exclude ...Iterator) Iterator {
+	return &exceptIterator{base: &peeker{it: base}, exclude: &peeker{it: Union(exclude...)}}
+}
+
+func (e *exceptIterator) Next() (time.Time, bool, error) {
+	for {
+		head, ok, err := e.base.peek()
+		if err != nil || !ok {
+			return time.Time{}, false, err
+		}
+
+		e.base.pop()
+
+		excluded := false
+
+		for {
+			ex, exOk, err := e.exclude.peek()
+			if err != nil {
+				return time.Time{}, false, err
+			}
+
+			if !exOk || ex.After(head) {
+				break
+			}
+
+			if ex.Equal(head) {
+				excluded = true
+			}
+
+			e.exclude.pop()
+		}
+
+		if !excluded {
+			return head, true, nil
+		}
+	}
+}
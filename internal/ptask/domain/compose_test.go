@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sliceIterator struct {
+	points []time.Time
+}
+
+func (s *sliceIterator) Next() (time.Time, bool, error) {
+	if len(s.points) == 0 {
+		return time.Time{}, false, nil
+	}
+
+	point := s.points[0]
+	s.points = s.points[1:]
+
+	return point, true, nil
+}
+
+func hours(hs ...int) Iterator {
+	points := make([]time.Time, 0, len(hs))
+	for _, h := range hs {
+		points = append(points, time.Date(2021, 7, 15, h, 0, 0, 0, time.UTC))
+	}
+
+	return &sliceIterator{points: points}
+}
+
+func collect(t *testing.T, it Iterator) []int {
+	t.Helper()
+
+	list := []int{}
+
+	for {
+		point, ok, err := it.Next()
+		require.NoError(t, err)
+
+		if !ok {
+			return list
+		}
+
+		list = append(list, point.Hour())
+	}
+}
+
+func TestCompose(t *testing.T) {
+	tt := []struct {
+		name     string
+		it       Iterator
+		expected []int
+	}{
+		{name: "union", it: Union(hours(0, 2, 4), hours(1, 2, 3)), expected: []int{0, 1, 2, 3, 4}},
+		{name: "union of nothing", it: Union(), expected: []int{}},
+		{name: "intersect", it: Intersect(hours(0, 2, 4, 6), hours(0, 3, 6), hours(0, 1, 6, 7)), expected: []int{0, 6}},
+		{name: "intersect disjoint", it: Intersect(hours(1, 3), hours(2, 4)), expected: []int{}},
+		{name: "except", it: Except(hours(0, 1, 2, 3, 4), hours(1, 3), hours(4)), expected: []int{0, 2}},
+		{name: "except nothing", it: Except(hours(0, 1)), expected: []int{0, 1}},
+		{name: "nested", it: Except(Union(hours(0, 2), hours(1, 3)), Intersect(hours(1, 2), hours(2, 3))), expected: []int{0, 1, 3}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, collect(t, tc.it))
+		})
+	}
+}
+
+func TestTaskIterator(t *testing.T) {
+	task := &PeriodicTask{
+		Period:          &Period{Value: 6, PeriodType: "h"},
+		InvocationPoint: time.Date(2021, 7, 15, 0, 0, 0, 0, time.UTC),
+		Timezone:        time.UTC,
+	}
+
+	it := NewTaskIterator(task, time.Date(2021, 7, 15, 18, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, []int{0, 6, 12}, collect(t, it))
+}
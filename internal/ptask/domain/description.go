@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/KarolosLykos/ptask/internal/constants"
+	"github.com/KarolosLykos/ptask/internal/utils/httperrors"
+)
+
+// PtDescription is the human-readable description of a period in a timezone.
+type PtDescription struct {
+	Period      string `json:"period"`
+	Timezone    string `json:"tz"`
+	Lang        string `json:"lang"`
+	Description string `json:"description"`
+}
+
+// phrase holds the singular and plural templates of a period type, the plural one taking the value.
+type phrase struct {
+	one  string
+	many string
+}
+
+// descriptions holds the localized templates per language and period type.
+// Every period is anchored at the start of its unit, i.e. 00:00 of the 1st for months.
+var descriptions = map[string]map[string]phrase{
+	constants.LangEnglish: {
+		constants.Year:  {one: "Every year on January 1st at 00:00", many: "Every %d years on January 1st at 00:00"},
+		constants.Month: {one: "Every month on the 1st at 00:00", many: "Every %d months on the 1st at 00:00"},
+		constants.Day:   {one: "Every day at 00:00", many: "Every %d days at 00:00"},
+		constants.Hour:  {one: "Every hour on the hour", many: "Every %d hours on the hour"},
+	},
+	constants.LangGreek: {
+		constants.Year:  {one: "Κάθε χρόνο την 1η Ιανουαρίου στις 00:00", many: "Κάθε %d χρόνια την 1η Ιανουαρίου στις 00:00"},
+		constants.Month: {one: "Κάθε μήνα την 1η στις 00:00", many: "Κάθε %d μήνες την 1η στις 00:00"},
+		constants.Day:   {one: "Κάθε μέρα στις 00:00", many: "Κάθε %d μέρες στις 00:00"},
+		constants.Hour:  {one: "Κάθε ώρα ακριβώς", many: "Κάθε %d ώρες ακριβώς"},
+	},
+	constants.LangGerman: {
+		constants.Year:  {one: "Jedes Jahr am 1. Januar um 00:00", many: "Alle %d Jahre am 1. Januar um 00:00"},
+		constants.Month: {one: "Jeden Monat am 1. um 00:00", many: "Alle %d Monate am 1. um 00:00"},
+		constants.Day:   {one: "Jeden Tag um 00:00", many: "Alle %d Tage um 00:00"},
+		constants.Hour:  {one: "Jede Stunde zur vollen Stunde", many: "Alle %d Stunden zur vollen Stunde"},
+	},
+}
+
+// Describe renders a period as a phrase such as "Every month on the 1st at 00:00 (Europe/Athens)".
+func Describe(period *Period, timezone *time.Location, lang string) (string, error) {
+	templates, ok := descriptions[strings.ToLower(lang)]
+	if !ok {
+		return "", httperrors.Wrapf(httperrors.ErrUnsupportedLanguage, "%s", lang)
+	}
+
+	p, ok := templates[period.PeriodType]
+	if !ok {
+		return "", httperrors.ErrInvalidPeriod
+	}
+
+	description := p.one
+	if period.Value != 1 {
+		description = fmt.Sprintf(p.many, period.Value)
+	}
+
+	return fmt.Sprintf("%s (%s)", description, timezone.String()), nil
+}
+
+// rulePhrase holds the templates of a Rule description in a language: at formats the time of day, daily the
+// rules of every week, weekly the rules of every n weeks and monthly the ordinal weekdays of a month.
+type rulePhrase struct {
+	at      string
+	daily   string
+	weekly  string
+	monthly string
+	// on names the weekdays of a weekly rule, every day and the working days being named by onEvery and onWork.
+	on      string
+	onEvery string
+	onWork  string
+	// every and work name every day and the working days in a daily rule.
+	every    string
+	work     string
+	and      string
+	weekdays [7]string
+	ordinal  func(n int, day time.Weekday) string
+}
+
+// ruleDescriptions holds the localized Rule templates per language.
+var ruleDescriptions = map[string]rulePhrase{
+	constants.LangEnglish: {
+		at:      "at %02d:%02d (%s)",
+		daily:   "Every %s %s",
+		weekly:  "Every %d weeks %s %s",
+		monthly: "On the %s %s of every month %s",
+		on:      "on %s",
+		onEvery: "on every day",
+		onWork:  "on weekdays",
+		every:   "day",
+		work:    "weekday",
+		and:     " and ",
+		weekdays: [7]string{
+			time.Sunday: "Sunday", time.Monday: "Monday", time.Tuesday: "Tuesday", time.Wednesday: "Wednesday",
+			time.Thursday: "Thursday", time.Friday: "Friday", time.Saturday: "Saturday",
+		},
+		ordinal: func(n int, _ time.Weekday) string { return ordinalNames[n] },
+	},
+	constants.LangGreek: {
+		at:      "στις %02d:%02d (%s)",
+		daily:   "Κάθε %s %s",
+		weekly:  "Κάθε %d εβδομάδες, %s, %s",
+		monthly: "Κάθε μήνα, %s %s, %s",
+		on:      "%s",
+		onEvery: "κάθε μέρα",
+		onWork:  "τις καθημερινές",
+		every:   "μέρα",
+		work:    "καθημερινή",
+		and:     " και ",
+		weekdays: [7]string{
+			time.Sunday: "Κυριακή", time.Monday: "Δευτέρα", time.Tuesday: "Τρίτη", time.Wednesday: "Τετάρτη",
+			time.Thursday: "Πέμπτη", time.Friday: "Παρασκευή", time.Saturday: "Σάββατο",
+		},
+		// the ordinal agrees with the gender of the weekday, Saturday being the only neuter one.
+		ordinal: func(n int, day time.Weekday) string {
+			if day == time.Saturday {
+				return map[int]string{1: "πρώτο", 2: "δεύτερο", 3: "τρίτο", 4: "τέταρτο", -1: "τελευταίο"}[n]
+			}
+
+			return map[int]string{1: "πρώτη", 2: "δεύτερη", 3: "τρίτη", 4: "τέταρτη", -1: "τελευταία"}[n]
+		},
+	},
+	constants.LangGerman: {
+		at:      "um %02d:%02d (%s)",
+		daily:   "Jeden %s %s",
+		weekly:  "Alle %d Wochen %s %s",
+		monthly: "Am %s %s jedes Monats %s",
+		on:      "am %s",
+		onEvery: "täglich",
+		onWork:  "werktags",
+		every:   "Tag",
+		work:    "Werktag",
+		and:     " und ",
+		weekdays: [7]string{
+			time.Sunday: "Sonntag", time.Monday: "Montag", time.Tuesday: "Dienstag", time.Wednesday: "Mittwoch",
+			time.Thursday: "Donnerstag", time.Friday: "Freitag", time.Saturday: "Samstag",
+		},
+		ordinal: func(n int, _ time.Weekday) string {
+			return map[int]string{1: "ersten", 2: "zweiten", 3: "dritten", 4: "vierten", -1: "letzten"}[n]
+		},
+	},
+}
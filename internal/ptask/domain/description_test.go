@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/ptask/internal/constants"
+	"github.com/KarolosLykos/ptask/internal/utils/httperrors"
+)
+
+func TestDescribe(t *testing.T) {
+	athens, err := time.LoadLocation("Europe/Athens")
+	require.NoError(t, err)
+
+	tt := []struct {
+		name        string
+		period      *Period
+		lang        string
+		description string
+		err         error
+	}{
+		{name: "unsupported language", period: &Period{Value: 1, PeriodType: constants.Month}, lang: "fr", err: httperrors.ErrUnsupportedLanguage},
+		{name: "invalid period", period: &Period{Value: 1, PeriodType: "wrong"}, lang: constants.LangEnglish, err: httperrors.ErrInvalidPeriod},
+		{name: "1mo en", period: &Period{Value: 1, PeriodType: constants.Month}, lang: constants.LangEnglish, description: "Every month on the 1st at 00:00 (Europe/Athens)"},
+		{name: "2y en", period: &Period{Value: 2, PeriodType: constants.Year}, lang: constants.LangEnglish, description: "Every 2 years on January 1st at 00:00 (Europe/Athens)"},
+		{name: "1d el", period: &Period{Value: 1, PeriodType: constants.Day}, lang: constants.LangGreek, description: "Κάθε μέρα στις 00:00 (Europe/Athens)"},
+		{name: "6h el", period: &Period{Value: 6, PeriodType: constants.Hour}, lang: constants.LangGreek, description: "Κάθε 6 ώρες ακριβώς (Europe/Athens)"},
+		{name: "3mo de", period: &Period{Value: 3, PeriodType: constants.Month}, lang: "DE", description: "Alle 3 Monate am 1. um 00:00 (Europe/Athens)"},
+		{name: "1h de", period: &Period{Value: 1, PeriodType: constants.Hour}, lang: constants.LangGerman, description: "Jede Stunde zur vollen Stunde (Europe/Athens)"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			description, err := Describe(tc.period, athens, tc.lang)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.description, description)
+			}
+		})
+	}
+}
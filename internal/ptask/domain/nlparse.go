@@ -0,0 +1,482 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/KarolosLykos/ptask/internal/constants"
+	"github.com/KarolosLykos/ptask/internal/utils/httperrors"
+	"github.com/KarolosLykos/ptask/pkg/schedule"
+)
+
+// PtParse is a natural-language schedule normalized to a period, or to an RRULE when no period expresses
+// it, with a preview of its next occurrences.
+type PtParse struct {
+	Query       string `json:"query"`
+	Period      string `json:"period,omitempty"`
+	Rule        string `json:"rule,omitempty"`
+	Description string `json:"description"`
+	Next        PtList `json:"next"`
+}
+
+// Schedule is a natural-language schedule parsed to a Period, or to a Rule when no period expresses it.
+type Schedule struct {
+	Period *Period
+	Rule   *Rule
+}
+
+// ParseError reports why a natural-language schedule could not be parsed.
+type ParseError struct {
+	Input    string `json:"input"`
+	Token    string `json:"token,omitempty"`
+	Position int    `json:"position"`
+	Reason   string `json:"reason"`
+}
+
+func (e *ParseError) Error() string {
+	if e.Token == "" {
+		return fmt.Sprintf("%s: %s", httperrors.ErrUnparsableSchedule, e.Reason)
+	}
+
+	return fmt.Sprintf("%s: %s at token %d %q", httperrors.ErrUnparsableSchedule, e.Reason, e.Position, e.Token)
+}
+
+func (e *ParseError) Unwrap() error {
+	return httperrors.ErrUnparsableSchedule
+}
+
+// unit is a period type multiplied by a factor, e.g. a week is 7 days.
+type unit struct {
+	periodType string
+	factor     int
+}
+
+var units = map[string]unit{
+	"hour": {constants.Hour, 1}, "hours": {constants.Hour, 1},
+	"day": {constants.Day, 1}, "days": {constants.Day, 1},
+	"week": {constants.Day, 7}, "weeks": {constants.Day, 7},
+	"fortnight": {constants.Day, 14}, "fortnights": {constants.Day, 14},
+	"month": {constants.Month, 1}, "months": {constants.Month, 1},
+	"quarter": {constants.Month, 3}, "quarters": {constants.Month, 3},
+	"year": {constants.Year, 1}, "years": {constants.Year, 1},
+}
+
+var adverbs = map[string]unit{
+	"hourly":      {constants.Hour, 1},
+	"daily":       {constants.Day, 1},
+	"weekly":      {constants.Day, 7},
+	"fortnightly": {constants.Day, 14},
+	"monthly":     {constants.Month, 1},
+	"quarterly":   {constants.Month, 3},
+	"yearly":      {constants.Year, 1},
+	"annually":    {constants.Year, 1},
+}
+
+var counts = map[string]int{
+	"a": 1, "an": 1, "one": 1, "other": 2, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6,
+	"seven": 7, "eight": 8, "nine": 9, "ten": 10, "eleven": 11, "twelve": 12,
+}
+
+var weekdays = map[string][]time.Weekday{
+	"monday": {time.Monday}, "mondays": {time.Monday}, "mon": {time.Monday},
+	"tuesday": {time.Tuesday}, "tuesdays": {time.Tuesday}, "tue": {time.Tuesday},
+	"wednesday": {time.Wednesday}, "wednesdays": {time.Wednesday}, "wed": {time.Wednesday},
+	"thursday": {time.Thursday}, "thursdays": {time.Thursday}, "thu": {time.Thursday},
+	"friday": {time.Friday}, "fridays": {time.Friday}, "fri": {time.Friday},
+	"saturday": {time.Saturday}, "saturdays": {time.Saturday}, "sat": {time.Saturday},
+	"sunday": {time.Sunday}, "sundays": {time.Sunday}, "sun": {time.Sunday},
+	"weekday": workDays, "weekdays": workDays,
+	"weekend": {time.Saturday, time.Sunday}, "weekends": {time.Saturday, time.Sunday},
+}
+
+var ordinals = map[string]int{
+	"first": 1, "1st": 1, "second": 2, "2nd": 2, "third": 3, "3rd": 3, "fourth": 4, "4th": 4, "last": -1,
+}
+
+var subHour = map[string]bool{
+	"minute": true, "minutes": true, "min": true, "mins": true, "second": true, "seconds": true, "sec": true, "secs": true,
+}
+
+// anchors lists the phrases that match the anchor every period already has.
+var anchors = [][]string{
+	{"at", "midnight"}, {"at", "00:00"}, {"at", "0:00"}, {"at", "12am"}, {"at", "12", "am"},
+	{"on", "the", "hour"}, {"on", "the", "1st"}, {"on", "the", "first"}, {"of", "each", "month"}, {"of", "every", "month"},
+}
+
+// ParseSchedule turns a natural-language schedule into a Period when one expresses it, e.g. "every 2 hours",
+// or else into a Rule, e.g. "every weekday at 08:30", "every other Monday at 9am" or "first Monday of each
+// month". Input neither can express is rejected with a ParseError explaining why.
+func ParseSchedule(input string) (*Schedule, error) {
+	period, err := ParsePeriod(input)
+	if err == nil {
+		return &Schedule{Period: period}, nil
+	}
+
+	tokens := tokenize(input)
+	if !isRule(tokens) {
+		return nil, err
+	}
+
+	rule, err := parseRule(input, tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Schedule{Rule: rule}, nil
+}
+
+// ParsePeriod turns phrases such as "every 2 hours", "daily at midnight" or "every other week" into a Period.
+// Periods are always anchored at the start of their unit, so weekday rules and times other than midnight
+// are rejected with a ParseError explaining why, see ParseSchedule for those.
+func ParsePeriod(input string) (*Period, error) {
+	tokens := tokenize(input)
+
+	if len(tokens) == 0 {
+		return nil, &ParseError{Input: input, Reason: "empty schedule"}
+	}
+
+	for i, token := range tokens {
+		switch {
+		case weekdays[token] != nil:
+			return nil, &ParseError{Input: input, Token: token, Position: i, Reason: "weekday rules are not supported"}
+		case subHour[token]:
+			return nil, &ParseError{Input: input, Token: token, Position: i, Reason: "periods shorter than an hour are not supported"}
+		}
+	}
+
+	i := 0
+	if tokens[i] == "every" || tokens[i] == "each" {
+		i++
+	}
+
+	value := 1
+
+	if i < len(tokens) {
+		if a, ok := adverbs[tokens[i]]; ok && i == 0 {
+			return finishPeriod(input, tokens, i+1, a, value)
+		}
+
+		if n, err := strconv.Atoi(tokens[i]); err == nil {
+			value = n
+			i++
+		} else if n, ok := counts[tokens[i]]; ok {
+			value = n
+			i++
+		}
+	}
+
+	if i >= len(tokens) {
+		return nil, &ParseError{Input: input, Position: i, Reason: "missing unit, expected hours, days, weeks, months or years"}
+	}
+
+	u, ok := units[tokens[i]]
+	if !ok {
+		return nil, &ParseError{Input: input, Token: tokens[i], Position: i, Reason: "unknown unit, expected hours, days, weeks, months or years"}
+	}
+
+	if value <= 0 {
+		return nil, &ParseError{Input: input, Token: tokens[i-1], Position: i - 1, Reason: "count must be positive"}
+	}
+
+	// checked before the count is multiplied by the factor of the unit, which could overflow.
+	if max := schedule.MaxValue(u.periodType) / u.factor; value > max {
+		return nil, &ParseError{Input: input, Token: tokens[i-1], Position: i - 1, Reason: fmt.Sprintf("count must be at most %d %s", max, tokens[i])}
+	}
+
+	return finishPeriod(input, tokens, i+1, u, value)
+}
+
+// finishPeriod accepts optional trailing anchor phrases that match the default anchor of the unit.
+func finishPeriod(input string, tokens []string, i int, u unit, value int) (*Period, error) {
+	for i < len(tokens) {
+		n := matchAnchor(tokens[i:])
+		if n == 0 {
+			reason := "unexpected trailing words, only the default anchor (midnight, on the hour, on the 1st) is supported"
+			if tokens[i] == "at" {
+				reason = "times other than midnight are not supported"
+			}
+
+			return nil, &ParseError{Input: input, Token: tokens[i], Position: i, Reason: reason}
+		}
+
+		i += n
+	}
+
+	return &Period{Value: value * u.factor, PeriodType: u.periodType}, nil
+}
+
+// matchAnchor returns the number of tokens of the anchor phrase the tokens start with, or 0.
+func matchAnchor(tokens []string) int {
+	for _, anchor := range anchors {
+		if len(tokens) >= len(anchor) && strings.Join(tokens[:len(anchor)], " ") == strings.Join(anchor, " ") {
+			return len(anchor)
+		}
+	}
+
+	return 0
+}
+
+func tokenize(input string) []string {
+	return strings.FieldsFunc(strings.ToLower(input), func(r rune) bool {
+		return unicode.IsSpace(r) || r == ','
+	})
+}
+
+// isRule reports whether the tokens name weekdays or a time of day, which only a Rule expresses.
+func isRule(tokens []string) bool {
+	for _, token := range tokens {
+		if weekdays[token] != nil || ordinals[token] != 0 || token == "at" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ruleParser reads the tokens of a Rule, such as "every other Monday at 9am", "every weekday at 08:30",
+// "every 2 weeks on Monday and Friday" or "the last Friday of each month at 17:00".
+type ruleParser struct {
+	input  string
+	tokens []string
+	i      int
+}
+
+func parseRule(input string, tokens []string) (*Rule, error) {
+	p := &ruleParser{input: input, tokens: tokens}
+	rule := &Rule{Interval: 1}
+
+	for i, token := range tokens {
+		if subHour[token] {
+			return nil, p.failAt(i, "periods shorter than an hour are not supported")
+		}
+	}
+
+	p.skip("every", "each")
+	p.skip("on")
+	p.skip("the")
+
+	if n, ok := ordinals[p.peek()]; ok {
+		return p.ordinal(rule, n)
+	}
+
+	if a, ok := adverbs[p.peek()]; ok {
+		p.i++
+
+		return p.weekly(rule, a, 1)
+	}
+
+	count, explicit := 1, false
+
+	if n, err := strconv.Atoi(p.peek()); err == nil {
+		count, explicit = n, true
+		p.i++
+	} else if n, ok := counts[p.peek()]; ok {
+		count, explicit = n, true
+		p.i++
+	}
+
+	if count <= 0 {
+		return nil, p.failAt(p.i-1, "count must be positive")
+	}
+
+	if u, ok := units[p.peek()]; ok {
+		p.i++
+
+		return p.weekly(rule, u, count)
+	}
+
+	if explicit {
+		// "every other Monday" repeats every other week.
+		rule.Interval = count
+	}
+
+	return p.days(rule)
+}
+
+// weekly reads the rest of a rule of days, hours being rejected, that repeats every count units.
+func (p *ruleParser) weekly(rule *Rule, u unit, count int) (*Rule, error) {
+	switch {
+	case u.periodType == constants.Day && u.factor == 1 && count == 1:
+		// "every day at 08:30" is a rule of every day of the week.
+		rule.Weekdays = append([]time.Weekday(nil), everyDay...)
+
+		return p.at(rule)
+	case u.periodType == constants.Day && u.factor%7 == 0:
+		// the factor is at least a week, so counts over MaxRuleInterval are too long and would overflow.
+		if count > MaxRuleInterval {
+			return nil, p.failAt(p.i-2, fmt.Sprintf("intervals must be 1 to %d weeks", MaxRuleInterval))
+		}
+
+		rule.Interval = count * u.factor / 7
+
+		if !p.skip("on") {
+			return nil, p.fail("expected on and weekdays after weeks")
+		}
+
+		return p.days(rule)
+	}
+
+	return nil, p.failAt(p.i-1, "times other than midnight are only supported for days and weekdays")
+}
+
+// days reads a list of weekdays, such as "Monday, Wednesday and Friday", then the time of the rule.
+func (p *ruleParser) days(rule *Rule) (*Rule, error) {
+	for {
+		days, ok := weekdays[p.peek()]
+		if !ok {
+			break
+		}
+
+		rule.Weekdays = append(rule.Weekdays, days...)
+		p.i++
+
+		if !p.skip("and") {
+			continue
+		}
+
+		if weekdays[p.peek()] == nil {
+			return nil, p.fail("expected a weekday after and")
+		}
+	}
+
+	if len(rule.Weekdays) == 0 {
+		return nil, p.fail("expected a weekday")
+	}
+
+	if rule.Interval < 1 || rule.Interval > MaxRuleInterval {
+		return nil, p.fail(fmt.Sprintf("intervals must be 1 to %d weeks", MaxRuleInterval))
+	}
+
+	rule.Weekdays = sortDays(rule.Weekdays)
+
+	return p.at(rule)
+}
+
+// ordinal reads a rule such as "first Monday of each month", from its ordinal.
+func (p *ruleParser) ordinal(rule *Rule, n int) (*Rule, error) {
+	p.i++
+
+	days := weekdays[p.peek()]
+	if len(days) != 1 {
+		return nil, p.fail("expected a single weekday after " + ordinalNames[n])
+	}
+
+	rule.Ordinal, rule.Weekdays = n, days
+	p.i++
+
+	if !p.skip("of") || !p.skip("each", "every", "the") || !p.skip("month") {
+		return nil, p.fail("expected of each month")
+	}
+
+	return p.at(rule)
+}
+
+// at reads the optional time of a rule, midnight by default, which must end the input.
+func (p *ruleParser) at(rule *Rule) (*Rule, error) {
+	if p.skip("at") {
+		start := p.i
+
+		hour, minute, ok := p.time()
+		if !ok {
+			return nil, p.failAt(start, "expected a time such as 9am, 17:30, noon or midnight")
+		}
+
+		rule.Hour, rule.Minute = hour, minute
+	}
+
+	if p.i < len(p.tokens) {
+		return nil, p.fail("unexpected trailing words")
+	}
+
+	return rule, nil
+}
+
+// time reads a time of day such as "9am", "9 pm", "09:30", "9:30pm", "noon" or "midnight".
+func (p *ruleParser) time() (hour, minute int, ok bool) {
+	token := p.peek()
+
+	switch token {
+	case "noon":
+		p.i++
+		return 12, 0, true
+	case "midnight":
+		p.i++
+		return 0, 0, true
+	}
+
+	suffix := ""
+
+	for _, s := range []string{"am", "pm"} {
+		if strings.HasSuffix(token, s) {
+			token, suffix = strings.TrimSuffix(token, s), s
+		}
+	}
+
+	clock := strings.SplitN(token, ":", 2)
+
+	hour, err := strconv.Atoi(clock[0])
+	if err != nil || len(clock[0]) > 2 {
+		return 0, 0, false
+	}
+
+	if len(clock) == 2 {
+		if minute, err = strconv.Atoi(clock[1]); err != nil || len(clock[1]) != 2 || minute > 59 {
+			return 0, 0, false
+		}
+	}
+
+	p.i++
+
+	if suffix == "" && (p.peek() == "am" || p.peek() == "pm") {
+		suffix = p.peek()
+		p.i++
+	}
+
+	switch {
+	case suffix == "" && hour <= 23:
+		return hour, minute, true
+	case suffix == "" || hour < 1 || hour > 12:
+		return 0, 0, false
+	case suffix == "pm" && hour != 12:
+		hour += 12
+	case suffix == "am" && hour == 12:
+		hour = 0
+	}
+
+	return hour, minute, true
+}
+
+func (p *ruleParser) peek() string {
+	if p.i >= len(p.tokens) {
+		return ""
+	}
+
+	return p.tokens[p.i]
+}
+
+// skip moves past the next token when it is one of words, reporting whether it did.
+func (p *ruleParser) skip(words ...string) bool {
+	for _, w := range words {
+		if p.peek() == w {
+			p.i++
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *ruleParser) fail(reason string) error {
+	return p.failAt(p.i, reason)
+}
+
+func (p *ruleParser) failAt(i int, reason string) error {
+	if i >= len(p.tokens) {
+		return &ParseError{Input: p.input, Position: i, Reason: reason}
+	}
+
+	return &ParseError{Input: p.input, Token: p.tokens[i], Position: i, Reason: reason}
+}
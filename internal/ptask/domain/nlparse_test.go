@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/ptask/internal/constants"
+	"github.com/KarolosLykos/ptask/internal/utils/httperrors"
+)
+
+func TestParsePeriod(t *testing.T) {
+	tt := []struct {
+		input  string
+		period *Period
+		token  string
+	}{
+		{input: "every 2 hours", period: &Period{Value: 2, PeriodType: constants.Hour}},
+		{input: "Every hour on the hour", period: &Period{Value: 1, PeriodType: constants.Hour}},
+		{input: "hourly", period: &Period{Value: 1, PeriodType: constants.Hour}},
+		{input: "daily at midnight", period: &Period{Value: 1, PeriodType: constants.Day}},
+		{input: "every other day", period: &Period{Value: 2, PeriodType: constants.Day}},
+		{input: "every three weeks", period: &Period{Value: 21, PeriodType: constants.Day}},
+		{input: "fortnightly", period: &Period{Value: 14, PeriodType: constants.Day}},
+		{input: "every month on the 1st at 00:00", period: &Period{Value: 1, PeriodType: constants.Month}},
+		{input: "quarterly", period: &Period{Value: 3, PeriodType: constants.Month}},
+		{input: "each year", period: &Period{Value: 1, PeriodType: constants.Year}},
+		{input: "", token: ""},
+		{input: "every weekday at 08:30", token: "weekday"},
+		{input: "every other Monday at 9am", token: "monday"},
+		{input: "every 30 minutes", token: "minutes"},
+		{input: "every day at 08:30", token: "at"},
+		{input: "every day please", token: "please"},
+		{input: "every 2 fortnights and more", token: "and"},
+		{input: "every 2", token: ""},
+		{input: "every 0 days", token: "0"},
+		{input: "every 7905747460161236407 weeks", token: "7905747460161236407"},
+		{input: "every 14641 weeks", token: "14641"},
+		{input: "every decade", token: "decade"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.input, func(t *testing.T) {
+			period, err := ParsePeriod(tc.input)
+			if tc.period != nil {
+				require.NoError(t, err)
+				assert.Equal(t, tc.period, period)
+
+				return
+			}
+
+			assert.ErrorIs(t, err, httperrors.ErrUnparsableSchedule)
+
+			var parseErr *ParseError
+			require.True(t, errors.As(err, &parseErr))
+			assert.Equal(t, tc.token, parseErr.Token)
+			assert.NotEmpty(t, parseErr.Reason)
+		})
+	}
+}
+
+func TestParseSchedule(t *testing.T) {
+	tt := []struct {
+		input  string
+		period *Period
+		rule   *Rule
+		token  string
+		reason string
+	}{
+		{input: "every 2 hours", period: &Period{Value: 2, PeriodType: constants.Hour}},
+		{input: "every weekday at 08:30", rule: &Rule{Weekdays: workDays, Interval: 1, Hour: 8, Minute: 30}},
+		{input: "first Monday of each month", rule: &Rule{Weekdays: []time.Weekday{time.Monday}, Ordinal: 1, Interval: 1}},
+		{input: "every other Monday at 9am", rule: &Rule{Weekdays: []time.Weekday{time.Monday}, Interval: 2, Hour: 9}},
+		{input: "the last Friday of every month at 5:30 pm", rule: &Rule{Weekdays: []time.Weekday{time.Friday}, Ordinal: -1, Interval: 1, Hour: 17, Minute: 30}},
+		{input: "every day at noon", rule: &Rule{Weekdays: everyDay, Interval: 1, Hour: 12}},
+		{input: "daily at 12am", period: &Period{Value: 1, PeriodType: constants.Day}},
+		{input: "daily at 7pm", rule: &Rule{Weekdays: everyDay, Interval: 1, Hour: 19}},
+		{input: "weekly on Tuesday", rule: &Rule{Weekdays: []time.Weekday{time.Tuesday}, Interval: 1}},
+		{input: "every 2 weeks on Friday and Monday at 17:00", rule: &Rule{Weekdays: []time.Weekday{time.Monday, time.Friday}, Interval: 2, Hour: 17}},
+		{input: "on Sundays, Saturdays", rule: &Rule{Weekdays: []time.Weekday{time.Saturday, time.Sunday}, Interval: 1}},
+		{input: "every weekend at 10:00", rule: &Rule{Weekdays: []time.Weekday{time.Saturday, time.Sunday}, Interval: 1, Hour: 10}},
+		{input: "every day please", token: "please", reason: "unexpected trailing words, only the default anchor (midnight, on the hour, on the 1st) is supported"},
+		{input: "every weekday at 25:00", token: "25:00", reason: "expected a time such as 9am, 17:30, noon or midnight"},
+		{input: "every Monday at 13pm", token: "13pm", reason: "expected a time such as 9am, 17:30, noon or midnight"},
+		{input: "every 2 days at 08:30", token: "days", reason: "times other than midnight are only supported for days and weekdays"},
+		{input: "every 30 minutes at 9am", token: "minutes", reason: "periods shorter than an hour are not supported"},
+		{input: "first weekday of each month", token: "weekday", reason: "expected a single weekday after first"},
+		{input: "first Monday of the year", token: "year", reason: "expected of each month"},
+		{input: "every 2 weeks at 9am", token: "at", reason: "expected on and weekdays after weeks"},
+		{input: "every 100 Mondays", token: "", reason: "intervals must be 1 to 52 weeks"},
+		{input: "every 7905747460161236407 weeks on monday", token: "7905747460161236407", reason: "intervals must be 1 to 52 weeks"},
+		{input: "every 30 fortnights on monday", token: "", reason: "intervals must be 1 to 52 weeks"},
+		{input: "every Monday and", token: "", reason: "expected a weekday after and"},
+		{input: "every Monday at 9am sharp", token: "sharp", reason: "unexpected trailing words"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.input, func(t *testing.T) {
+			schedule, err := ParseSchedule(tc.input)
+			if tc.period != nil || tc.rule != nil {
+				require.NoError(t, err)
+				assert.Equal(t, &Schedule{Period: tc.period, Rule: tc.rule}, schedule)
+
+				return
+			}
+
+			var parseErr *ParseError
+			require.True(t, errors.As(err, &parseErr))
+			assert.Equal(t, tc.token, parseErr.Token)
+			assert.Equal(t, tc.reason, parseErr.Reason)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/KarolosLykos/ptask/internal/constants"
+	"github.com/KarolosLykos/ptask/internal/utils/httperrors"
+)
+
+// PtOccurrence is a matching timestamp along with its local rendering in every display timezone.
+type PtOccurrence struct {
+	Timestamp string            `json:"timestamp"`
+	Local     map[string]string `json:"local"`
+}
+
+// PtDisplayList is a PtList rendered in the display timezones, along with the description of its period.
+type PtDisplayList struct {
+	List        []PtOccurrence `json:"list"`
+	Description string         `json:"description"`
+}
+
+// PtDisplaySchedule is a PtSchedule rendered in the display timezones.
+type PtDisplaySchedule struct {
+	List        []PtOccurrence `json:"list"`
+	Suppressed  []PtOccurrence `json:"suppressed"`
+	Description string         `json:"description,omitempty"`
+}
+
+// Display renders every timestamp of the list in each of the zones, keyed by zone name.
+func Display(list PtList, zones []*time.Location) ([]PtOccurrence, error) {
+	occurrences := make([]PtOccurrence, 0, len(list))
+
+	for _, timestamp := range list {
+		point, err := time.Parse(constants.TimestampLayout, timestamp)
+		if err != nil {
+			return nil, httperrors.Wrapf(httperrors.ErrInternalServer, "could not parse timestamp %q: %v", timestamp, err)
+		}
+
+		local := make(map[string]string, len(zones))
+		for _, zone := range zones {
+			local[zone.String()] = point.In(zone).Format(time.RFC3339)
+		}
+
+		occurrences = append(occurrences, PtOccurrence{Timestamp: timestamp, Local: local})
+	}
+
+	return occurrences, nil
+}
+
+// Occurrence is a timestamp of the v2 API, in RFC 3339, along with its local rendering in every zone and
+// whether a blackout suppressed it.
+type Occurrence struct {
+	Timestamp  string            `json:"timestamp"`
+	Local      map[string]string `json:"local"`
+	Suppressed bool              `json:"suppressed"`
+}
+
+// OccurrenceList is the list of occurrences of the v2 API along with the description of their period.
+type OccurrenceList struct {
+	Description string       `json:"description"`
+	Occurrences []Occurrence `json:"occurrences"`
+}
+
+// Occurrences merges the matching and the suppressed occurrences in chronological order.
+func Occurrences(list, suppressed []PtOccurrence) ([]Occurrence, error) {
+	occurrences := make([]Occurrence, 0, len(list)+len(suppressed))
+
+	for len(list) > 0 || len(suppressed) > 0 {
+		// timestamps share the same layout, so they sort lexicographically.
+		isSuppressed := len(list) == 0 || (len(suppressed) > 0 && suppressed[0].Timestamp < list[0].Timestamp)
+
+		var next PtOccurrence
+		if isSuppressed {
+			next, suppressed = suppressed[0], suppressed[1:]
+		} else {
+			next, list = list[0], list[1:]
+		}
+
+		point, err := time.Parse(constants.TimestampLayout, next.Timestamp)
+		if err != nil {
+			return nil, httperrors.Wrapf(httperrors.ErrInternalServer, "could not parse timestamp %q: %v", next.Timestamp, err)
+		}
+
+		occurrences = append(occurrences, Occurrence{Timestamp: point.Format(time.RFC3339), Local: next.Local, Suppressed: isSuppressed})
+	}
+
+	return occurrences, nil
+}
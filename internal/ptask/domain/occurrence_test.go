@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisplay(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	occurrences, err := Display(PtList{"20210728T210000Z", "20211231T220000Z"}, []*time.Location{newYork, tokyo})
+	require.NoError(t, err)
+
+	assert.Equal(t, []PtOccurrence{
+		{Timestamp: "20210728T210000Z", Local: map[string]string{"America/New_York": "2021-07-28T17:00:00-04:00", "Asia/Tokyo": "2021-07-29T06:00:00+09:00"}},
+		{Timestamp: "20211231T220000Z", Local: map[string]string{"America/New_York": "2021-12-31T17:00:00-05:00", "Asia/Tokyo": "2022-01-01T07:00:00+09:00"}},
+	}, occurrences)
+
+	occurrences, err = Display(PtList{}, []*time.Location{tokyo})
+	require.NoError(t, err)
+	assert.Empty(t, occurrences)
+
+	_, err = Display(PtList{"wrong"}, []*time.Location{tokyo})
+	assert.Error(t, err)
+}
+
+func TestOccurrences(t *testing.T) {
+	list := []PtOccurrence{
+		{Timestamp: "20210728T210000Z", Local: map[string]string{"Europe/Athens": "2021-07-29T00:00:00+03:00"}},
+		{Timestamp: "20210730T210000Z", Local: map[string]string{"Europe/Athens": "2021-07-31T00:00:00+03:00"}},
+	}
+	suppressed := []PtOccurrence{
+		{Timestamp: "20210729T210000Z", Local: map[string]string{"Europe/Athens": "2021-07-30T00:00:00+03:00"}},
+	}
+
+	occurrences, err := Occurrences(list, suppressed)
+	require.NoError(t, err)
+
+	assert.Equal(t, []Occurrence{
+		{Timestamp: "2021-07-28T21:00:00Z", Local: map[string]string{"Europe/Athens": "2021-07-29T00:00:00+03:00"}},
+		{Timestamp: "2021-07-29T21:00:00Z", Local: map[string]string{"Europe/Athens": "2021-07-30T00:00:00+03:00"}, Suppressed: true},
+		{Timestamp: "2021-07-30T21:00:00Z", Local: map[string]string{"Europe/Athens": "2021-07-31T00:00:00+03:00"}},
+	}, occurrences)
+
+	occurrences, err = Occurrences(nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, occurrences)
+
+	_, err = Occurrences([]PtOccurrence{{Timestamp: "wrong"}}, nil)
+	assert.Error(t, err)
+}
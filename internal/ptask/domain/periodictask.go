@@ -4,21 +4,15 @@ import (
 	"context"
 	"time"
 
-	"github.com/KarolosLykos/ptask/internal/constants"
 	"github.com/KarolosLykos/ptask/internal/logger"
+	"github.com/KarolosLykos/ptask/internal/tracing"
 	"github.com/KarolosLykos/ptask/internal/utils/httperrors"
+	"github.com/KarolosLykos/ptask/pkg/schedule"
 )
 
-type PeriodicTask struct {
-	Period          *Period
-	InvocationPoint time.Time
-	Timezone        *time.Location
-}
+type PeriodicTask = schedule.PeriodicTask
 
-type Period struct {
-	Value      int
-	PeriodType string
-}
+type Period = schedule.Period
 
 type PtList []string
 
@@ -32,42 +26,18 @@ func NewPeriodicTask(
 	logger.Trace(ctx, "periodicTask.NewPeriodicTask")
 	defer logger.Trace(ctx, "periodicTask.NewPeriodicTask")
 
-	invocationPoint, err := getInvocationPoint(ctx, logger, period, startPoint)
-	if err != nil {
-		return nil, err
-	}
+	_, span := tracing.Start(ctx, "periodicTask.NewPeriodicTask",
+		tracing.PeriodKey.String(period.String()),
+		tracing.TimezoneKey.String(timezone.String()),
+	)
 
-	return &PeriodicTask{Period: period, InvocationPoint: invocationPoint, Timezone: timezone}, nil
-}
+	task, err := schedule.New(period, timezone, startPoint)
+	if err == nil {
+		span.SetAttributes(tracing.InvocationPointKey.String(task.InvocationPoint.Format(time.RFC3339)))
+	}
 
-func getInvocationPoint(
-	ctx context.Context,
-	logger logger.Logger,
-	period *Period,
-	startPoint time.Time,
-) (time.Time, error) {
-	logger.Trace(ctx, "periodicTask.getInvocationPoint")
-	defer logger.Trace(ctx, "periodicTask.getInvocationPoint")
+	err = httperrors.WrapPeriod(err)
+	tracing.End(span, err)
 
-	switch period.PeriodType {
-	case constants.Year:
-		return time.Date(startPoint.Year()+1, 1, 1, 0, 0, 0, 0, startPoint.Location()), nil
-	case constants.Month:
-		return time.Date(startPoint.Year(), startPoint.Month()+1, 1, 0, 0, 0, 0, startPoint.Location()), nil
-	case constants.Day:
-		return time.Date(startPoint.Year(), startPoint.Month(), startPoint.Day()+1, 0, 0, 0, 0, startPoint.Location()), nil
-	case constants.Hour:
-		return time.Date(
-			startPoint.Year(),
-			startPoint.Month(),
-			startPoint.Day(),
-			startPoint.Hour()+1,
-			0,
-			0,
-			0,
-			startPoint.Location(),
-		), nil
-	default:
-		return time.Time{}, httperrors.ErrInvalidPeriod
-	}
+	return task, err
 }
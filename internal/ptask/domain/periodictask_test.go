@@ -17,7 +17,7 @@ import (
 	"github.com/KarolosLykos/ptask/internal/utils/httperrors"
 )
 
-func TestGetInvocationPoint(t *testing.T) {
+func TestNewPeriodicTask(t *testing.T) {
 	ctx := context.Background()
 	l := getLogger()
 
@@ -81,7 +81,7 @@ func TestGetInvocationPoint(t *testing.T) {
 			start, err := time.Parse(format, tc.start)
 			require.NoError(t, err)
 
-			invocationPoint, err := getInvocationPoint(ctx, l, tc.period, start)
+			task, err := NewPeriodicTask(ctx, l, tc.period, nil, start)
 			if err != nil && tc.err != nil {
 				assert.ErrorIs(t, err, tc.err)
 			} else {
@@ -90,7 +90,7 @@ func TestGetInvocationPoint(t *testing.T) {
 				expected, err := time.Parse(format, tc.invocationPoint)
 				require.NoError(t, err)
 
-				assert.Equal(t, expected, invocationPoint)
+				assert.Equal(t, expected, task.InvocationPoint)
 			}
 		})
 	}
@@ -0,0 +1,182 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/KarolosLykos/ptask/internal/utils/httperrors"
+)
+
+// MaxRuleInterval bounds the number of weeks between the occurrences of a Rule.
+const MaxRuleInterval = 52
+
+// Rule is a schedule no Period expresses: the days of the week it matches at a time of day, every Interval
+// weeks, or only the Ordinal one of those days in a month, -1 being the last.
+type Rule struct {
+	Weekdays []time.Weekday
+	Ordinal  int
+	Interval int
+	Hour     int
+	Minute   int
+}
+
+var (
+	everyDay = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday}
+	workDays = everyDay[:5]
+)
+
+var rruleDays = map[time.Weekday]string{
+	time.Monday: "MO", time.Tuesday: "TU", time.Wednesday: "WE", time.Thursday: "TH", time.Friday: "FR",
+	time.Saturday: "SA", time.Sunday: "SU",
+}
+
+var ordinalNames = map[int]string{1: "first", 2: "second", 3: "third", 4: "fourth", -1: "last"}
+
+// String formats the rule as an RFC 5545 RRULE, e.g. "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO;BYHOUR=9;BYMINUTE=0".
+func (r *Rule) String() string {
+	days := make([]string, 0, len(r.Weekdays))
+	for _, d := range r.Weekdays {
+		days = append(days, rruleDays[d])
+	}
+
+	if r.Ordinal != 0 {
+		return fmt.Sprintf("FREQ=MONTHLY;BYDAY=%d%s;BYHOUR=%d;BYMINUTE=%d", r.Ordinal, days[0], r.Hour, r.Minute)
+	}
+
+	return fmt.Sprintf("FREQ=WEEKLY;INTERVAL=%d;BYDAY=%s;BYHOUR=%d;BYMINUTE=%d", r.Interval, strings.Join(days, ","), r.Hour, r.Minute)
+}
+
+// Describe renders the rule in a language, e.g. "Every 2 weeks on Monday at 09:00 (Europe/Athens)" in English.
+func (r *Rule) Describe(timezone *time.Location, lang string) (string, error) {
+	phrase, ok := ruleDescriptions[strings.ToLower(lang)]
+	if !ok {
+		return "", httperrors.Wrapf(httperrors.ErrUnsupportedLanguage, "%s", lang)
+	}
+
+	at := fmt.Sprintf(phrase.at, r.Hour, r.Minute, timezone.String())
+
+	switch {
+	case r.Ordinal != 0:
+		day := r.Weekdays[0]
+
+		return fmt.Sprintf(phrase.monthly, phrase.ordinal(r.Ordinal, day), phrase.weekdays[day], at), nil
+	case r.Interval > 1:
+		return fmt.Sprintf(phrase.weekly, r.Interval, r.days(phrase, phrase.onEvery, phrase.onWork, phrase.on), at), nil
+	}
+
+	return fmt.Sprintf(phrase.daily, r.days(phrase, phrase.every, phrase.work, "%s"), at), nil
+}
+
+// days names the weekdays of the rule in the language of phrase, formatted by named, every day and the
+// working days by the given phrases.
+func (r *Rule) days(phrase rulePhrase, every, work, named string) string {
+	switch {
+	case sameDays(r.Weekdays, everyDay):
+		return every
+	case sameDays(r.Weekdays, workDays):
+		return work
+	}
+
+	names := make([]string, 0, len(r.Weekdays))
+	for _, d := range r.Weekdays {
+		names = append(names, phrase.weekdays[d])
+	}
+
+	if len(names) == 1 {
+		return fmt.Sprintf(named, names[0])
+	}
+
+	return fmt.Sprintf(named, strings.Join(names[:len(names)-1], ", ")+phrase.and+names[len(names)-1])
+}
+
+// Next returns the n first occurrences of the rule at or after start, in timezone. Intervals of weeks are
+// counted from the week of start, which starts on Monday.
+func (r *Rule) Next(start time.Time, timezone *time.Location, n int) []time.Time {
+	start = start.In(timezone)
+	anchor := weekOf(start)
+
+	occurrences := make([]time.Time, 0, n)
+
+	// every rule matches a day at least every Interval weeks, or every month, so the loop ends.
+	for day := start; len(occurrences) < n; day = day.AddDate(0, 0, 1) {
+		if !r.matches(day, anchor) {
+			continue
+		}
+
+		point := time.Date(day.Year(), day.Month(), day.Day(), r.Hour, r.Minute, 0, 0, timezone)
+		if point.Before(start) {
+			continue
+		}
+
+		occurrences = append(occurrences, point)
+	}
+
+	return occurrences
+}
+
+func (r *Rule) matches(day, anchor time.Time) bool {
+	if !containsDay(r.Weekdays, day.Weekday()) {
+		return false
+	}
+
+	switch {
+	case r.Ordinal > 0:
+		return (day.Day()-1)/7+1 == r.Ordinal
+	case r.Ordinal < 0:
+		return day.AddDate(0, 0, 7).Month() != day.Month()
+	}
+
+	weeks := int(weekOf(day).Sub(anchor).Hours()) / (7 * 24)
+
+	return weeks%r.Interval == 0
+}
+
+// weekOf returns the date, in UTC so days last 24 hours, of the Monday starting the week of t.
+func weekOf(t time.Time) time.Time {
+	date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	return date.AddDate(0, 0, -(int(date.Weekday())+6)%7)
+}
+
+// sortDays sorts weekdays from Monday to Sunday and drops the duplicates.
+func sortDays(days []time.Weekday) []time.Weekday {
+	sort.Slice(days, func(i, j int) bool {
+		return (days[i]+6)%7 < (days[j]+6)%7
+	})
+
+	sorted := days[:0]
+
+	for i, d := range days {
+		if i == 0 || d != days[i-1] {
+			sorted = append(sorted, d)
+		}
+	}
+
+	return sorted
+}
+
+func sameDays(a, b []time.Weekday) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsDay(days []time.Weekday, day time.Weekday) bool {
+	for _, d := range days {
+		if d == day {
+			return true
+		}
+	}
+
+	return false
+}
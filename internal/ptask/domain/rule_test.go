@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/ptask/internal/constants"
+	"github.com/KarolosLykos/ptask/internal/utils/httperrors"
+)
+
+func TestRule(t *testing.T) {
+	athens, err := time.LoadLocation("Europe/Athens")
+	require.NoError(t, err)
+
+	// a Wednesday, 23:46 in Athens.
+	start := time.Date(2021, 7, 14, 20, 46, 3, 0, time.UTC)
+
+	tt := []struct {
+		name        string
+		rule        *Rule
+		rrule       string
+		description string
+		next        []string
+	}{
+		{
+			name:        "weekdays",
+			rule:        &Rule{Weekdays: workDays, Interval: 1, Hour: 8, Minute: 30},
+			rrule:       "FREQ=WEEKLY;INTERVAL=1;BYDAY=MO,TU,WE,TH,FR;BYHOUR=8;BYMINUTE=30",
+			description: "Every weekday at 08:30 (Europe/Athens)",
+			next:        []string{"20210715T053000Z", "20210716T053000Z", "20210719T053000Z"},
+		},
+		{
+			name:        "every other week",
+			rule:        &Rule{Weekdays: []time.Weekday{time.Monday, time.Friday}, Interval: 2, Hour: 17},
+			rrule:       "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR;BYHOUR=17;BYMINUTE=0",
+			description: "Every 2 weeks on Monday and Friday at 17:00 (Europe/Athens)",
+			next:        []string{"20210716T140000Z", "20210726T140000Z", "20210730T140000Z"},
+		},
+		{
+			name:        "first monday",
+			rule:        &Rule{Weekdays: []time.Weekday{time.Monday}, Ordinal: 1, Interval: 1},
+			rrule:       "FREQ=MONTHLY;BYDAY=1MO;BYHOUR=0;BYMINUTE=0",
+			description: "On the first Monday of every month at 00:00 (Europe/Athens)",
+			next:        []string{"20210801T210000Z", "20210905T210000Z", "20211003T210000Z"},
+		},
+		{
+			name:        "last friday",
+			rule:        &Rule{Weekdays: []time.Weekday{time.Friday}, Ordinal: -1, Interval: 1, Hour: 17, Minute: 30},
+			rrule:       "FREQ=MONTHLY;BYDAY=-1FR;BYHOUR=17;BYMINUTE=30",
+			description: "On the last Friday of every month at 17:30 (Europe/Athens)",
+			next:        []string{"20210730T143000Z", "20210827T143000Z", "20210924T143000Z"},
+		},
+		{
+			name:        "every day",
+			rule:        &Rule{Weekdays: everyDay, Interval: 1, Hour: 23, Minute: 50},
+			rrule:       "FREQ=WEEKLY;INTERVAL=1;BYDAY=MO,TU,WE,TH,FR,SA,SU;BYHOUR=23;BYMINUTE=50",
+			description: "Every day at 23:50 (Europe/Athens)",
+			next:        []string{"20210714T205000Z", "20210715T205000Z", "20210716T205000Z"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.rrule, tc.rule.String())
+			description, err := tc.rule.Describe(athens, constants.LangEnglish)
+			require.NoError(t, err)
+			assert.Equal(t, tc.description, description)
+
+			next := make([]string, 0, len(tc.next))
+			for _, occurrence := range tc.rule.Next(start, athens, len(tc.next)) {
+				next = append(next, occurrence.UTC().Format(constants.TimestampLayout))
+			}
+
+			assert.Equal(t, tc.next, next)
+		})
+	}
+}
+
+func TestRule_Describe(t *testing.T) {
+	athens, err := time.LoadLocation("Europe/Athens")
+	require.NoError(t, err)
+
+	weekly := &Rule{Weekdays: []time.Weekday{time.Monday, time.Friday}, Interval: 2, Hour: 17}
+	fortnightly := &Rule{Weekdays: everyDay, Interval: 2, Hour: 9}
+	weekdays := &Rule{Weekdays: workDays, Interval: 1, Hour: 8, Minute: 30}
+	firstSaturday := &Rule{Weekdays: []time.Weekday{time.Saturday}, Ordinal: 1, Interval: 1}
+	lastMonday := &Rule{Weekdays: []time.Weekday{time.Monday}, Ordinal: -1, Interval: 1}
+
+	tt := []struct {
+		rule        *Rule
+		lang        string
+		description string
+	}{
+		{rule: weekly, lang: constants.LangGreek, description: "Κάθε 2 εβδομάδες, Δευτέρα και Παρασκευή, στις 17:00 (Europe/Athens)"},
+		{rule: fortnightly, lang: constants.LangGreek, description: "Κάθε 2 εβδομάδες, κάθε μέρα, στις 09:00 (Europe/Athens)"},
+		{rule: weekdays, lang: constants.LangGreek, description: "Κάθε καθημερινή στις 08:30 (Europe/Athens)"},
+		{rule: firstSaturday, lang: constants.LangGreek, description: "Κάθε μήνα, πρώτο Σάββατο, στις 00:00 (Europe/Athens)"},
+		{rule: lastMonday, lang: constants.LangGreek, description: "Κάθε μήνα, τελευταία Δευτέρα, στις 00:00 (Europe/Athens)"},
+		{rule: weekly, lang: constants.LangGerman, description: "Alle 2 Wochen am Montag und Freitag um 17:00 (Europe/Athens)"},
+		{rule: fortnightly, lang: constants.LangGerman, description: "Alle 2 Wochen täglich um 09:00 (Europe/Athens)"},
+		{rule: weekdays, lang: "DE", description: "Jeden Werktag um 08:30 (Europe/Athens)"},
+		{rule: firstSaturday, lang: constants.LangGerman, description: "Am ersten Samstag jedes Monats um 00:00 (Europe/Athens)"},
+		{rule: fortnightly, lang: constants.LangEnglish, description: "Every 2 weeks on every day at 09:00 (Europe/Athens)"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.lang+" "+tc.description, func(t *testing.T) {
+			description, err := tc.rule.Describe(athens, tc.lang)
+			require.NoError(t, err)
+			assert.Equal(t, tc.description, description)
+		})
+	}
+
+	_, err = weekly.Describe(athens, "fr")
+	assert.ErrorIs(t, err, httperrors.ErrUnsupportedLanguage)
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/KarolosLykos/ptask/internal/constants"
+)
+
+// PtTimezone is the state of a timezone at a point in time.
+type PtTimezone struct {
+	Name          string `json:"name"`
+	Abbreviation  string `json:"abbreviation"`
+	Offset        string `json:"offset"`
+	OffsetSeconds int    `json:"offset_seconds"`
+	DST           bool   `json:"dst"`
+}
+
+// PtTransition is an offset change of a timezone.
+type PtTransition struct {
+	At     string     `json:"at"`
+	Before PtTimezone `json:"before"`
+	After  PtTimezone `json:"after"`
+}
+
+// NewPtTimezone returns the offset and abbreviation of loc at the given point.
+func NewPtTimezone(loc *time.Location, at time.Time) PtTimezone {
+	t := at.In(loc)
+	abbreviation, offset := t.Zone()
+
+	return PtTimezone{
+		Name:          loc.String(),
+		Abbreviation:  abbreviation,
+		Offset:        formatOffset(offset),
+		OffsetSeconds: offset,
+		DST:           t.IsDST(),
+	}
+}
+
+// Transitions lists every offset or abbreviation change of loc in [t1, t2).
+func Transitions(loc *time.Location, t1, t2 time.Time) []PtTransition {
+	transitions := []PtTransition{}
+
+	for t := t1.In(loc); t.Before(t2); {
+		_, end := t.ZoneBounds()
+		if end.IsZero() || !end.After(t) || !end.Before(t2) {
+			break
+		}
+
+		before, after := NewPtTimezone(loc, t), NewPtTimezone(loc, end)
+		if before != after {
+			transitions = append(transitions, PtTransition{
+				At:     end.UTC().Format(constants.TimestampLayout),
+				Before: before,
+				After:  after,
+			})
+		}
+
+		t = end
+	}
+
+	return transitions
+}
+
+func formatOffset(offset int) string {
+	sign := '+'
+	if offset < 0 {
+		sign, offset = '-', -offset
+	}
+
+	return fmt.Sprintf("%c%02d:%02d", sign, offset/3600, offset/60%60)
+}
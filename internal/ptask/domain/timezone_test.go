@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPtTimezone(t *testing.T) {
+	athens, err := time.LoadLocation("Europe/Athens")
+	require.NoError(t, err)
+
+	assert.Equal(t, PtTimezone{Name: "Europe/Athens", Abbreviation: "EEST", Offset: "+03:00", OffsetSeconds: 10800, DST: true},
+		NewPtTimezone(athens, time.Date(2021, 7, 14, 0, 0, 0, 0, time.UTC)))
+
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	assert.Equal(t, PtTimezone{Name: "America/New_York", Abbreviation: "EST", Offset: "-05:00", OffsetSeconds: -18000},
+		NewPtTimezone(newYork, time.Date(2021, 1, 14, 0, 0, 0, 0, time.UTC)))
+
+	assert.Equal(t, "+05:30", NewPtTimezone(time.FixedZone("IST", 19800), time.Now()).Offset)
+}
+
+func TestTransitions(t *testing.T) {
+	athens, err := time.LoadLocation("Europe/Athens")
+	require.NoError(t, err)
+
+	transitions := Transitions(athens, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.Len(t, transitions, 2)
+
+	assert.Equal(t, "20210328T010000Z", transitions[0].At)
+	assert.Equal(t, "EET", transitions[0].Before.Abbreviation)
+	assert.Equal(t, "EEST", transitions[0].After.Abbreviation)
+	assert.Equal(t, "20211031T010000Z", transitions[1].At)
+	assert.Equal(t, "+02:00", transitions[1].After.Offset)
+
+	assert.Empty(t, Transitions(time.UTC, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Empty(t, Transitions(athens, time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC)))
+}
@@ -0,0 +1,91 @@
+// Package gql serves the periodic task use case over GraphQL.
+package gql
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+
+	"github.com/KarolosLykos/ptask/internal/logger"
+	"github.com/KarolosLykos/ptask/internal/ptask"
+)
+
+// request is a GraphQL request, sent as the JSON body of a POST or as the query string of a GET.
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+type Handler struct {
+	logger logger.Logger
+	schema graphql.Schema
+}
+
+func NewHandler(logger logger.Logger, useCase ptask.UseCase) (*Handler, error) {
+	schema, err := NewSchema(logger, useCase)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Handler{logger: logger, schema: schema}, nil
+}
+
+// ServeHTTP executes a query once it is within the depth and cost limits. Responses follow the GraphQL
+// over HTTP convention: {"data": ..., "errors": [...]}.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req := &request{}
+
+	if r.Method == http.MethodGet {
+		req.Query = r.URL.Query().Get("query")
+		req.OperationName = r.URL.Query().Get("operationName")
+
+		if variables := r.URL.Query().Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				h.write(w, http.StatusBadRequest, &graphql.Result{Errors: gqlerrors.FormatErrors(err)})
+				return
+			}
+		}
+	} else if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		h.write(w, http.StatusBadRequest, &graphql.Result{Errors: gqlerrors.FormatErrors(err)})
+		return
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(req.Query)})})
+	if err != nil {
+		h.write(w, http.StatusBadRequest, &graphql.Result{Errors: gqlerrors.FormatErrors(err)})
+		return
+	}
+
+	if err = checkLimits(doc, req.OperationName, req.Variables); err != nil {
+		h.logger.Error(ctx, err, "query over limits")
+		h.write(w, http.StatusBadRequest, &graphql.Result{Errors: gqlerrors.FormatErrors(err)})
+
+		return
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:         h.schema,
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  req.OperationName,
+		Context:        ctx,
+	})
+
+	h.write(w, http.StatusOK, result)
+}
+
+func (h *Handler) write(w http.ResponseWriter, statusCode int, result *graphql.Result) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	p, _ := json.Marshal(result)
+
+	_, _ = w.Write(p)
+}
@@ -0,0 +1,271 @@
+package gql
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/KarolosLykos/ptask/internal/constants"
+	"github.com/KarolosLykos/ptask/internal/logger"
+	"github.com/KarolosLykos/ptask/internal/logger/log"
+	"github.com/KarolosLykos/ptask/internal/ptask/domain"
+	mock_ptask "github.com/KarolosLykos/ptask/internal/ptask/mock"
+	"github.com/KarolosLykos/ptask/internal/ptask/usecase"
+	"github.com/KarolosLykos/ptask/internal/utils"
+)
+
+type result struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+const window = `period: "1d", tz: "Europe/Athens", t1: "20210728T204603Z", t2: "20210731T123456Z"`
+
+func TestHandler(t *testing.T) {
+	l := getLogger()
+
+	h, err := NewHandler(l, usecase.NewPeriodicTaskUC(l))
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	tt := []struct {
+		name       string
+		query      string
+		variables  string
+		statusCode int
+		data       string
+		err        string
+		errStatus  float64
+	}{
+		{
+			name:       "schedule",
+			query:      `{ schedule(` + window + `) { period tz description occurrences { timestamp } } }`,
+			statusCode: http.StatusOK,
+			data: `{"schedule":{"period":"1d","tz":"Europe/Athens","description":"Every day at 00:00 (Europe/Athens)",` +
+				`"occurrences":[{"timestamp":"20210728T210000Z"},{"timestamp":"20210729T210000Z"},{"timestamp":"20210730T210000Z"}]}}`,
+		},
+		{
+			name:       "first and display timezones",
+			query:      `{ schedule(` + window + `) { occurrences(first: 1, displayTz: ["America/New_York"]) { timestamp local { tz time } } } }`,
+			statusCode: http.StatusOK,
+			data:       `{"schedule":{"occurrences":[{"timestamp":"20210728T210000Z","local":[{"tz":"America/New_York","time":"2021-07-28T17:00:00-04:00"}]}]}}`,
+		},
+		{
+			name:       "suppressed",
+			query:      `{ schedule(` + window + `, blackouts: ["20210729T000000Z/20210730T000000Z"]) { occurrences { timestamp } suppressed { timestamp } } }`,
+			statusCode: http.StatusOK,
+			data: `{"schedule":{"occurrences":[{"timestamp":"20210728T210000Z"},{"timestamp":"20210730T210000Z"}],` +
+				`"suppressed":[{"timestamp":"20210729T210000Z"}]}}`,
+		},
+		{
+			name:       "description",
+			query:      `query Describe($lang: String) { description(period: "1mo", tz: "Europe/Athens", lang: $lang) { description } }`,
+			variables:  `{"lang": "de"}`,
+			statusCode: http.StatusOK,
+			data:       `{"description":{"description":"Jeden Monat am 1. um 00:00 (Europe/Athens)"}}`,
+		},
+		{
+			name:       "timezones",
+			query:      `{ timezones(search: "Europe/Ath", first: 1) { name } }`,
+			statusCode: http.StatusOK,
+			data:       `{"timezones":[{"name":"Europe/Athens"}]}`,
+		},
+		{
+			name:       "transitions",
+			query:      `{ transitions(tz: "Europe/Athens", t1: "20210101T000000Z", t2: "20220101T000000Z") { at before { offset } after { offset } } }`,
+			statusCode: http.StatusOK,
+			data: `{"transitions":[{"after":{"offset":"+03:00"},"at":"20210328T010000Z","before":{"offset":"+02:00"}},` +
+				`{"after":{"offset":"+02:00"},"at":"20211031T010000Z","before":{"offset":"+03:00"}}]}`,
+		},
+		{
+			name:       "invalid period",
+			query:      `{ schedule(period: "1w", t1: "20210728T204603Z", t2: "20210731T123456Z") { period } }`,
+			statusCode: http.StatusOK,
+			err:        "invalid period",
+			errStatus:  http.StatusBadRequest,
+		},
+		{
+			name:       "invalid first",
+			query:      `{ schedule(` + window + `) { occurrences(first: 0) { timestamp } } }`,
+			statusCode: http.StatusOK,
+			err:        "invalid count",
+			errStatus:  http.StatusBadRequest,
+		},
+		{
+			name:       "syntax error",
+			query:      `{ schedule(`,
+			statusCode: http.StatusBadRequest,
+			err:        "Syntax Error",
+		},
+		{
+			name:       "too deep",
+			query:      `{ a { b { c { d { e { f { g { h { i { j } } } } } } } } } }`,
+			statusCode: http.StatusBadRequest,
+			err:        "query too deep",
+		},
+		{
+			name: "too expensive",
+			query: `{ schedule(` + window + `) {
+				a: occurrences(first: 1000) { timestamp local { tz time } }
+				b: occurrences(first: 1000) { timestamp local { tz time } }
+				c: occurrences(first: 1000) { timestamp local { tz time } }
+			} }`,
+			statusCode: http.StatusBadRequest,
+			err:        "query too expensive",
+		},
+		{
+			name:       "window too expensive",
+			query:      `{ schedule(period: "1h", t1: "20210101T000000Z", t2: "20220301T000000Z") { occurrences(first: 1) { timestamp } } }`,
+			statusCode: http.StatusBadRequest,
+			err:        "query too expensive",
+		},
+		{
+			name:       "window too expensive through variables",
+			query:      `query Q($p: String!, $t2: String!) { schedule(period: $p, t1: "20210101T000000Z", t2: $t2) { period } }`,
+			variables:  `{"p": "1h", "t2": "99991231T000000Z"}`,
+			statusCode: http.StatusBadRequest,
+			err:        "query too expensive",
+		},
+		{
+			name:       "window within the cost",
+			query:      `{ schedule(period: "1h", t1: "20210101T000000Z", t2: "20211201T000000Z") { occurrences(first: 1) { timestamp } } }`,
+			statusCode: http.StatusOK,
+			data:       `{"schedule":{"occurrences":[{"timestamp":"20210101T010000Z"}]}}`,
+		},
+		{
+			name:       "too expensive through variables and fragments",
+			query:      `query Q($n: Int) { schedule(` + window + `) { ...F } } fragment F on Schedule { a: occurrences(first: $n) { ...O } b: suppressed(first: $n) { ...O } c: occurrences(first: $n) { ...O } } fragment O on Occurrence { timestamp local { tz time } }`,
+			variables:  `{"n": 1000}`,
+			statusCode: http.StatusBadRequest,
+			err:        "query too expensive",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			body := `{"query": ` + quote(tc.query)
+			if tc.variables != "" {
+				body += `, "variables": ` + tc.variables
+			}
+
+			body += `}`
+
+			res, err := http.Post(srv.URL, "application/json", strings.NewReader(body))
+			require.NoError(t, err)
+
+			defer res.Body.Close()
+
+			assertResult(t, res, tc.statusCode, tc.data, tc.err, tc.errStatus)
+		})
+	}
+
+	t.Run("get", func(t *testing.T) {
+		q := url.Values{}
+		q.Set("query", `{ description(period: "2h") { description } }`)
+
+		res, err := http.Get(srv.URL + "?" + q.Encode())
+		require.NoError(t, err)
+
+		defer res.Body.Close()
+
+		assertResult(t, res, http.StatusOK, `{"description":{"description":"Every 2 hours on the hour (UTC)"}}`, "", 0)
+	})
+}
+
+func TestHandler_Limit(t *testing.T) {
+	tt := []struct {
+		name  string
+		query string
+		limit int
+	}{
+		{name: "first", query: `{ schedule(` + window + `) { a: occurrences(first: 2) { timestamp } b: occurrences(first: 3) { timestamp } } }`, limit: 3},
+		{name: "default first", query: `{ schedule(` + window + `) { ... on Schedule { occurrences { timestamp } } } }`, limit: constants.GraphQLDefaultFirst},
+		{name: "fragment", query: `query Q($n: Int) { schedule(` + window + `) { ...F } } fragment F on Schedule { occurrences(first: $n) { timestamp } }`, limit: 1},
+		{name: "suppressed", query: `{ schedule(` + window + `) { occurrences(first: 1) { timestamp } suppressed(first: 1) { timestamp } } }`, limit: 0},
+		{name: "no occurrences", query: `{ schedule(` + window + `) { period } }`, limit: 0},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			useCase := mock_ptask.NewMockUseCase(ctrl)
+			useCase.EXPECT().GetSchedule(gomock.Any(), gomock.Any()).Times(1).
+				DoAndReturn(func(_ context.Context, params *utils.ListQueryParams) (*domain.PtSchedule, error) {
+					assert.Equal(t, tc.limit, params.Limit)
+					return &domain.PtSchedule{List: domain.PtList{}, Suppressed: domain.PtList{}}, nil
+				})
+
+			h, err := NewHandler(getLogger(), useCase)
+			require.NoError(t, err)
+
+			body := `{"query": ` + quote(tc.query) + `, "variables": {"n": 1}}`
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+			assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		})
+	}
+}
+
+func assertResult(t *testing.T, res *http.Response, statusCode int, data, errMsg string, errStatus float64) {
+	t.Helper()
+
+	require.Equal(t, statusCode, res.StatusCode)
+	assert.Equal(t, "application/json", res.Header.Get("Content-Type"))
+
+	r := &result{}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(r))
+
+	if errMsg != "" {
+		require.NotEmpty(t, r.Errors)
+		assert.Contains(t, r.Errors[0].Message, errMsg)
+
+		if errStatus != 0 {
+			assert.Equal(t, errStatus, r.Errors[0].Extensions["status"])
+		}
+
+		return
+	}
+
+	require.Empty(t, r.Errors)
+
+	p, err := json.Marshal(r.Data)
+	require.NoError(t, err)
+	assert.JSONEq(t, data, string(p))
+}
+
+func quote(s string) string {
+	p, _ := json.Marshal(s)
+	return string(p)
+}
+
+func getLogger() logger.Logger {
+	l := &logrus.Logger{
+		Out:          io.Discard,
+		Hooks:        make(logrus.LevelHooks),
+		ReportCaller: false,
+		ExitFunc:     os.Exit,
+		Level:        logrus.DebugLevel,
+		Formatter:    &logrus.JSONFormatter{},
+	}
+
+	return log.New(l)
+}
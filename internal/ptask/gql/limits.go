@@ -0,0 +1,263 @@
+package gql
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/graphql-go/graphql/language/ast"
+
+	"github.com/KarolosLykos/ptask/internal/constants"
+	"github.com/KarolosLykos/ptask/internal/utils/httperrors"
+	ptschedule "github.com/KarolosLykos/ptask/pkg/schedule"
+)
+
+var (
+	ErrQueryTooDeep      = httperrors.New("query_too_deep", http.StatusBadRequest, "query too deep")
+	ErrQueryTooExpensive = httperrors.New("query_too_expensive", http.StatusBadRequest, "query too expensive")
+)
+
+// listFields are the fields that return up to first items, first defaulting to GraphQLDefaultFirst.
+var listFields = map[string]bool{
+	"occurrences": true,
+	"suppressed":  true,
+	"timezones":   true,
+	"transitions": true,
+}
+
+// shortestUnits are the shortest each period type lasts, so windows are never counted as holding fewer
+// occurrences than they do.
+var shortestUnits = map[string]time.Duration{
+	constants.Year:  365 * 24 * time.Hour,
+	constants.Month: 28 * 24 * time.Hour,
+	constants.Day:   23 * time.Hour,
+	constants.Hour:  time.Hour,
+}
+
+// analysis computes the depth and cost of the operation of a query before it runs. Every field costs one,
+// plus the cost of its selection multiplied by first for list fields, so the cost bounds the number of
+// values the query can resolve. A schedule also costs the number of occurrences of its window, which are
+// computed whatever first is.
+type analysis struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+	visiting  map[string]bool
+}
+
+// checkLimits rejects operations deeper than MaxGraphQLDepth or costing more than MaxGraphQLCost.
+func checkLimits(doc *ast.Document, operationName string, variables map[string]interface{}) error {
+	a := &analysis{fragments: map[string]*ast.FragmentDefinition{}, variables: variables, visiting: map[string]bool{}}
+
+	var operations []*ast.OperationDefinition
+
+	for _, definition := range doc.Definitions {
+		switch d := definition.(type) {
+		case *ast.FragmentDefinition:
+			a.fragments[d.Name.Value] = d
+		case *ast.OperationDefinition:
+			if operationName == "" || (d.Name != nil && d.Name.Value == operationName) {
+				operations = append(operations, d)
+			}
+		}
+	}
+
+	for _, operation := range operations {
+		depth, cost := a.selectionSet(operation.SelectionSet, 0)
+
+		if depth > constants.MaxGraphQLDepth {
+			return httperrors.Wrapf(ErrQueryTooDeep, "depth %d exceeds %d", depth, constants.MaxGraphQLDepth)
+		}
+
+		if cost > constants.MaxGraphQLCost {
+			return httperrors.Wrapf(ErrQueryTooExpensive, "cost %d exceeds %d", cost, constants.MaxGraphQLCost)
+		}
+	}
+
+	return nil
+}
+
+// selectionSet returns the depth and cost of a selection set found at the given depth.
+func (a *analysis) selectionSet(set *ast.SelectionSet, depth int) (int, int) {
+	if set == nil {
+		return depth, 0
+	}
+
+	// stop walking as soon as the depth is over the limit, the query is rejected anyway.
+	if depth > constants.MaxGraphQLDepth {
+		return depth, 0
+	}
+
+	maxDepth, cost := depth, 0
+
+	for _, selection := range set.Selections {
+		var d, c int
+
+		switch s := selection.(type) {
+		case *ast.Field:
+			d, c = a.field(s, depth)
+		case *ast.InlineFragment:
+			d, c = a.selectionSet(s.SelectionSet, depth)
+		case *ast.FragmentSpread:
+			d, c = a.fragmentSpread(s, depth)
+		}
+
+		if d > maxDepth {
+			maxDepth = d
+		}
+
+		cost += c
+		if cost > constants.MaxGraphQLCost {
+			return maxDepth, cost
+		}
+	}
+
+	return maxDepth, cost
+}
+
+func (a *analysis) field(field *ast.Field, depth int) (int, int) {
+	// introspection is bounded by the schema.
+	if strings.HasPrefix(field.Name.Value, "__") {
+		return depth, 0
+	}
+
+	d, c := a.selectionSet(field.SelectionSet, depth+1)
+
+	if listFields[field.Name.Value] {
+		c *= a.first(field)
+	}
+
+	if field.Name.Value == "schedule" {
+		c += a.window(field)
+	}
+
+	return d, 1 + c
+}
+
+// window returns the most occurrences the window of a schedule field holds. Arguments the resolver rejects
+// count as none.
+func (a *analysis) window(field *ast.Field) int {
+	period, err := ptschedule.ParsePeriod(a.str(field, "period"))
+	if err != nil {
+		return 0
+	}
+
+	t1, err1 := time.Parse(constants.TimestampLayout, a.str(field, "t1"))
+	t2, err2 := time.Parse(constants.TimestampLayout, a.str(field, "t2"))
+
+	if err1 != nil || err2 != nil || !t1.Before(t2) {
+		return 0
+	}
+
+	n := t2.Sub(t1) / shortestUnits[period.PeriodType] / time.Duration(period.Value)
+
+	// a window over MaxGraphQLCost periods is rejected anyway, stop there so the cost cannot overflow.
+	if n >= constants.MaxGraphQLCost {
+		return constants.MaxGraphQLCost + 1
+	}
+
+	return int(n) + 1
+}
+
+// str returns the string argument name of field, given inline or as a variable.
+func (a *analysis) str(field *ast.Field, name string) string {
+	for _, arg := range field.Arguments {
+		if arg.Name.Value != name {
+			continue
+		}
+
+		switch v := arg.Value.(type) {
+		case *ast.StringValue:
+			return v.Value
+		case *ast.Variable:
+			s, _ := a.variables[v.Name.Value].(string)
+			return s
+		}
+	}
+
+	return ""
+}
+
+// maxFirst returns the largest first of the fields named name selected in set, or 0 when there are none.
+func (a *analysis) maxFirst(set *ast.SelectionSet, name string) int {
+	if set == nil {
+		return 0
+	}
+
+	n := 0
+
+	for _, selection := range set.Selections {
+		var m int
+
+		switch s := selection.(type) {
+		case *ast.Field:
+			if s.Name.Value == name {
+				m = a.first(s)
+			}
+		case *ast.InlineFragment:
+			m = a.maxFirst(s.SelectionSet, name)
+		case *ast.FragmentSpread:
+			if fragment, ok := a.fragments[s.Name.Value]; ok && !a.visiting[s.Name.Value] {
+				a.visiting[s.Name.Value] = true
+				m = a.maxFirst(fragment.SelectionSet, name)
+				delete(a.visiting, s.Name.Value)
+			}
+		}
+
+		if m > n {
+			n = m
+		}
+	}
+
+	return n
+}
+
+func (a *analysis) fragmentSpread(spread *ast.FragmentSpread, depth int) (int, int) {
+	name := spread.Name.Value
+
+	fragment, ok := a.fragments[name]
+	if !ok || a.visiting[name] {
+		// unknown fragments and cycles fail the validation of the query.
+		return depth, 0
+	}
+
+	a.visiting[name] = true
+	defer delete(a.visiting, name)
+
+	return a.selectionSet(fragment.SelectionSet, depth)
+}
+
+// first returns the first argument of a list field, given inline or as a variable. Values the resolvers
+// reject are counted as GraphQLDefaultFirst or MaxGraphQLFirst so they cannot skew the cost.
+func (a *analysis) first(field *ast.Field) int {
+	n := constants.GraphQLDefaultFirst
+
+	for _, arg := range field.Arguments {
+		if arg.Name.Value != "first" {
+			continue
+		}
+
+		switch v := arg.Value.(type) {
+		case *ast.IntValue:
+			if i, err := strconv.Atoi(v.Value); err == nil {
+				n = i
+			}
+		case *ast.Variable:
+			switch i := a.variables[v.Name.Value].(type) {
+			case int:
+				n = i
+			case float64:
+				n = int(i)
+			}
+		}
+	}
+
+	switch {
+	case n <= 0:
+		return constants.GraphQLDefaultFirst
+	case n > constants.MaxGraphQLFirst:
+		return constants.MaxGraphQLFirst
+	default:
+		return n
+	}
+}
@@ -0,0 +1,401 @@
+package gql
+
+import (
+	"context"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+
+	"github.com/KarolosLykos/ptask/internal/constants"
+	"github.com/KarolosLykos/ptask/internal/logger"
+	"github.com/KarolosLykos/ptask/internal/ptask"
+	"github.com/KarolosLykos/ptask/internal/ptask/domain"
+	"github.com/KarolosLykos/ptask/internal/utils"
+	"github.com/KarolosLykos/ptask/internal/utils/httperrors"
+)
+
+// schedule is the resolved value of the Schedule type.
+type schedule struct {
+	params   *utils.ListQueryParams
+	schedule *domain.PtSchedule
+}
+
+// localTime is a timestamp rendered in a display timezone.
+type localTime struct {
+	Tz   string `json:"tz"`
+	Time string `json:"time"`
+}
+
+// occurrence is the resolved value of the Occurrence type.
+type occurrence struct {
+	Timestamp string
+	Local     []localTime
+}
+
+type resolver struct {
+	logger  logger.Logger
+	useCase ptask.UseCase
+}
+
+// NewSchema builds the GraphQL schema of schedules, descriptions and timezones, resolved through the use case.
+func NewSchema(logger logger.Logger, useCase ptask.UseCase) (graphql.Schema, error) {
+	r := &resolver{logger: logger, useCase: useCase}
+
+	first := &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: constants.GraphQLDefaultFirst}
+	stringList := graphql.NewList(graphql.NewNonNull(graphql.String))
+
+	timezoneType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Timezone",
+		Fields: graphql.Fields{
+			"name":          &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: field(func(tz domain.PtTimezone) interface{} { return tz.Name })},
+			"abbreviation":  &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: field(func(tz domain.PtTimezone) interface{} { return tz.Abbreviation })},
+			"offset":        &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: field(func(tz domain.PtTimezone) interface{} { return tz.Offset })},
+			"offsetSeconds": &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: field(func(tz domain.PtTimezone) interface{} { return tz.OffsetSeconds })},
+			"dst":           &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean), Resolve: field(func(tz domain.PtTimezone) interface{} { return tz.DST })},
+		},
+	})
+
+	transitionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Transition",
+		Fields: graphql.Fields{
+			"at":     &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: field(func(t domain.PtTransition) interface{} { return t.At })},
+			"before": &graphql.Field{Type: graphql.NewNonNull(timezoneType), Resolve: field(func(t domain.PtTransition) interface{} { return t.Before })},
+			"after":  &graphql.Field{Type: graphql.NewNonNull(timezoneType), Resolve: field(func(t domain.PtTransition) interface{} { return t.After })},
+		},
+	})
+
+	localTimeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "LocalTime",
+		Fields: graphql.Fields{
+			"tz":   &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: field(func(l localTime) interface{} { return l.Tz })},
+			"time": &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: field(func(l localTime) interface{} { return l.Time })},
+		},
+	})
+
+	occurrenceType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Occurrence",
+		Fields: graphql.Fields{
+			"timestamp": &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: field(func(o occurrence) interface{} { return o.Timestamp })},
+			"local":     &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(localTimeType))), Resolve: field(func(o occurrence) interface{} { return o.Local })},
+		},
+	})
+
+	descriptionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Description",
+		Fields: graphql.Fields{
+			"period":      &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: field(func(d *domain.PtDescription) interface{} { return d.Period })},
+			"tz":          &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: field(func(d *domain.PtDescription) interface{} { return d.Timezone })},
+			"lang":        &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: field(func(d *domain.PtDescription) interface{} { return d.Lang })},
+			"description": &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: field(func(d *domain.PtDescription) interface{} { return d.Description })},
+		},
+	})
+
+	scheduleType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Schedule",
+		Fields: graphql.Fields{
+			"period": &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: field(func(s *schedule) interface{} { return s.params.Period.String() })},
+			"tz":     &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: field(func(s *schedule) interface{} { return s.params.Timezone.String() })},
+			"description": &graphql.Field{
+				Type:    graphql.NewNonNull(graphql.String),
+				Args:    graphql.FieldConfigArgument{"lang": {Type: graphql.String, DefaultValue: constants.LangEnglish}},
+				Resolve: r.scheduleDescription,
+			},
+			"occurrences": &graphql.Field{
+				Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(occurrenceType))),
+				Args:    graphql.FieldConfigArgument{"first": first, "displayTz": {Type: stringList}},
+				Resolve: r.occurrences,
+			},
+			"suppressed": &graphql.Field{
+				Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(occurrenceType))),
+				Args:    graphql.FieldConfigArgument{"first": first, "displayTz": {Type: stringList}},
+				Resolve: r.suppressed,
+			},
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"schedule": &graphql.Field{
+				Type: graphql.NewNonNull(scheduleType),
+				Args: graphql.FieldConfigArgument{
+					"period":       {Type: graphql.NewNonNull(graphql.String)},
+					"tz":           {Type: graphql.String, DefaultValue: "UTC"},
+					"t1":           {Type: graphql.NewNonNull(graphql.String)},
+					"t2":           {Type: graphql.NewNonNull(graphql.String)},
+					"blackouts":    {Type: stringList},
+					"blackoutSets": {Type: stringList},
+				},
+				Resolve: r.schedule,
+			},
+			"description": &graphql.Field{
+				Type: graphql.NewNonNull(descriptionType),
+				Args: graphql.FieldConfigArgument{
+					"period": {Type: graphql.NewNonNull(graphql.String)},
+					"tz":     {Type: graphql.String, DefaultValue: "UTC"},
+					"lang":   {Type: graphql.String, DefaultValue: constants.LangEnglish},
+				},
+				Resolve: r.description,
+			},
+			"timezones": &graphql.Field{
+				Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(timezoneType))),
+				Args:    graphql.FieldConfigArgument{"search": {Type: graphql.String, DefaultValue: ""}, "first": first},
+				Resolve: r.timezones,
+			},
+			"transitions": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(transitionType))),
+				Args: graphql.FieldConfigArgument{
+					"tz":    {Type: graphql.NewNonNull(graphql.String)},
+					"t1":    {Type: graphql.NewNonNull(graphql.String)},
+					"t2":    {Type: graphql.NewNonNull(graphql.String)},
+					"first": first,
+				},
+				Resolve: r.transitions,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query})
+}
+
+func (r *resolver) schedule(p graphql.ResolveParams) (interface{}, error) {
+	params, err := utils.GetListQueryParams(p.Context, r.logger, str(p.Args, "period"), str(p.Args, "tz"), str(p.Args, "t1"), str(p.Args, "t2"))
+	if err != nil {
+		return nil, r.error(p.Context, err, "could not parse schedule arguments")
+	}
+
+	if params.Blackouts, err = utils.GetBlackouts(p.Context, r.logger, strs(p.Args, "blackouts")); err != nil {
+		return nil, r.error(p.Context, err, "could not parse blackouts")
+	}
+
+	params.BlackoutSets = strs(p.Args, "blackoutSets")
+	params.Limit = limit(p)
+
+	s, err := r.useCase.GetSchedule(p.Context, params)
+	if err != nil {
+		return nil, r.error(p.Context, err, "could not get matching task schedule")
+	}
+
+	return &schedule{params: params, schedule: s}, nil
+}
+
+// limit returns the most occurrences the fields selected on a schedule list, so the use case stops once it
+// listed them. Suppressed occurrences are only all found by going through the whole window, in which case
+// there is no limit.
+func limit(p graphql.ResolveParams) int {
+	a := &analysis{fragments: map[string]*ast.FragmentDefinition{}, variables: p.Info.VariableValues, visiting: map[string]bool{}}
+
+	for name, definition := range p.Info.Fragments {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok {
+			a.fragments[name] = fragment
+		}
+	}
+
+	n := 0
+
+	for _, field := range p.Info.FieldASTs {
+		if a.maxFirst(field.SelectionSet, "suppressed") > 0 {
+			return 0
+		}
+
+		if m := a.maxFirst(field.SelectionSet, "occurrences"); m > n {
+			n = m
+		}
+	}
+
+	return n
+}
+
+func (r *resolver) scheduleDescription(p graphql.ResolveParams) (interface{}, error) {
+	s, _ := p.Source.(*schedule)
+
+	d, err := r.useCase.Describe(p.Context, &utils.DescribeParams{Period: s.params.Period, Timezone: s.params.Timezone, Lang: str(p.Args, "lang")})
+	if err != nil {
+		return nil, r.error(p.Context, err, "could not describe schedule")
+	}
+
+	return d.Description, nil
+}
+
+func (r *resolver) occurrences(p graphql.ResolveParams) (interface{}, error) {
+	s, _ := p.Source.(*schedule)
+
+	return r.display(p, s.schedule.List)
+}
+
+func (r *resolver) suppressed(p graphql.ResolveParams) (interface{}, error) {
+	s, _ := p.Source.(*schedule)
+
+	return r.display(p, s.schedule.Suppressed)
+}
+
+// display returns the first timestamps of the list, rendered in the display timezones when there are any.
+func (r *resolver) display(p graphql.ResolveParams, list domain.PtList) (interface{}, error) {
+	n, err := firstArg(p.Args)
+	if err != nil {
+		return nil, r.error(p.Context, err, "could not parse first")
+	}
+
+	if len(list) > n {
+		list = list[:n]
+	}
+
+	zones, err := utils.GetDisplayZones(p.Context, r.logger, strs(p.Args, "displayTz"))
+	if err != nil {
+		return nil, r.error(p.Context, err, "could not parse display timezones")
+	}
+
+	occurrences := make([]occurrence, 0, len(list))
+
+	if len(zones) == 0 {
+		for _, timestamp := range list {
+			occurrences = append(occurrences, occurrence{Timestamp: timestamp, Local: []localTime{}})
+		}
+
+		return occurrences, nil
+	}
+
+	displayed, err := r.useCase.Display(p.Context, list, zones)
+	if err != nil {
+		return nil, r.error(p.Context, err, "could not display occurrences")
+	}
+
+	for _, o := range displayed {
+		local := make([]localTime, 0, len(zones))
+		for _, zone := range zones {
+			local = append(local, localTime{Tz: zone.String(), Time: o.Local[zone.String()]})
+		}
+
+		occurrences = append(occurrences, occurrence{Timestamp: o.Timestamp, Local: local})
+	}
+
+	return occurrences, nil
+}
+
+func (r *resolver) description(p graphql.ResolveParams) (interface{}, error) {
+	params, err := utils.GetDescribeQueryParams(p.Context, r.logger, str(p.Args, "period"), str(p.Args, "tz"), str(p.Args, "lang"))
+	if err != nil {
+		return nil, r.error(p.Context, err, "could not parse description arguments")
+	}
+
+	d, err := r.useCase.Describe(p.Context, params)
+	if err != nil {
+		return nil, r.error(p.Context, err, "could not describe schedule")
+	}
+
+	return d, nil
+}
+
+func (r *resolver) timezones(p graphql.ResolveParams) (interface{}, error) {
+	n, err := firstArg(p.Args)
+	if err != nil {
+		return nil, r.error(p.Context, err, "could not parse first")
+	}
+
+	timezones, err := r.useCase.Timezones(p.Context, str(p.Args, "search"))
+	if err != nil {
+		return nil, r.error(p.Context, err, "could not list timezones")
+	}
+
+	if len(timezones) > n {
+		timezones = timezones[:n]
+	}
+
+	return timezones, nil
+}
+
+func (r *resolver) transitions(p graphql.ResolveParams) (interface{}, error) {
+	n, err := firstArg(p.Args)
+	if err != nil {
+		return nil, r.error(p.Context, err, "could not parse first")
+	}
+
+	params, err := utils.GetTransitionsQueryParams(p.Context, r.logger, str(p.Args, "tz"), str(p.Args, "t1"), str(p.Args, "t2"))
+	if err != nil {
+		return nil, r.error(p.Context, err, "could not parse transitions arguments")
+	}
+
+	transitions, err := r.useCase.Transitions(p.Context, params)
+	if err != nil {
+		return nil, r.error(p.Context, err, "could not list transitions")
+	}
+
+	if len(transitions) > n {
+		transitions = transitions[:n]
+	}
+
+	return transitions, nil
+}
+
+// error logs err and reports it with the message of its sentinel and the status the REST API would answer with.
+func (r *resolver) error(ctx context.Context, err error, msg string) error {
+	r.logger.Error(ctx, err, msg)
+
+	return newError(err)
+}
+
+// Error is a resolver error carrying the HTTP status of its sentinel as an extension.
+type Error struct {
+	err    error
+	msg    string
+	status int
+}
+
+func newError(err error) *Error {
+	e := httperrors.From(err)
+
+	return &Error{err: err, msg: e.Message, status: e.Status}
+}
+
+func (e *Error) Error() string {
+	return e.msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+func (e *Error) Extensions() map[string]interface{} {
+	return map[string]interface{}{"status": e.status}
+}
+
+// firstArg returns the bounded first argument of a list field.
+func firstArg(args map[string]interface{}) (int, error) {
+	n, _ := args["first"].(int)
+	if n <= 0 || n > constants.MaxGraphQLFirst {
+		return 0, httperrors.Wrapf(httperrors.ErrInvalidCount, "first must be between 1 and %d", constants.MaxGraphQLFirst)
+	}
+
+	return n, nil
+}
+
+// field resolves a field from a source of type T.
+func field[T any](get func(T) interface{}) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		source, ok := p.Source.(T)
+		if !ok {
+			return nil, httperrors.Wrapf(httperrors.ErrInternalServer, "unexpected source %T", p.Source)
+		}
+
+		return get(source), nil
+	}
+}
+
+func str(args map[string]interface{}, name string) string {
+	s, _ := args[name].(string)
+	return s
+}
+
+func strs(args map[string]interface{}, name string) []string {
+	values, _ := args[name].([]interface{})
+
+	list := make([]string, 0, len(values))
+
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			list = append(list, s)
+		}
+	}
+
+	return list
+}
@@ -6,4 +6,11 @@ import (
 
 type Handlers interface {
 	List() func(w http.ResponseWriter, r *http.Request)
+	ListV2() func(w http.ResponseWriter, r *http.Request)
+	Compose() func(w http.ResponseWriter, r *http.Request)
+	Collisions() func(w http.ResponseWriter, r *http.Request)
+	Describe() func(w http.ResponseWriter, r *http.Request)
+	Parse() func(w http.ResponseWriter, r *http.Request)
+	Timezones() func(w http.ResponseWriter, r *http.Request)
+	Transitions() func(w http.ResponseWriter, r *http.Request)
 }
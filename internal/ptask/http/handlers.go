@@ -1,11 +1,21 @@
 package http
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/gorilla/mux"
+
+	"github.com/KarolosLykos/ptask/internal/constants"
 	"github.com/KarolosLykos/ptask/internal/logger"
 	"github.com/KarolosLykos/ptask/internal/ptask"
+	"github.com/KarolosLykos/ptask/internal/ptask/domain"
 	"github.com/KarolosLykos/ptask/internal/utils"
+	"github.com/KarolosLykos/ptask/internal/utils/httperrors"
 	"github.com/KarolosLykos/ptask/internal/utils/response"
 )
 
@@ -24,27 +34,476 @@ func NewTaskHandler(logger logger.Logger, useCase ptask.UseCase) *TaskHandler {
 // List returns all matching timestamps of a periodic task
 //
 //	@Summary		Returns all matching timestamps of a periodic task between 2 points in time.
+//	@Tags			v1
 //	@Accept			json
 //	@Produce		json
-//	@Param			period	query	string	false	"Period"		example(1y,1mo,1d,1h)
-//	@Param			tz		query	string	false	"Timezone"		example(America/Los_Angeles)
-//	@Param			t1		query	string	false	"Start point"	example(20060102T150405Z)
-//	@Param			t2		query	string	false	"End point"		example(20060102T150405Z)
+//	@Param			period			query	string		false	"Period"							example(1y,1mo,1d,1h)
+//	@Param			tz				query	string		false	"Timezone"							example(America/Los_Angeles)
+//	@Param			t1				query	string		false	"Start point"						example(20060102T150405Z)
+//	@Param			t2				query	string		false	"End point"							example(20060102T150405Z)
+//	@Param			blackout		query	[]string	false	"Blackout interval (t1/t2)"			collectionFormat(multi)
+//	@Param			blackout_set	query	[]string	false	"Named blackout set"				collectionFormat(multi)
+//	@Param			show_suppressed	query	bool		false	"Report suppressed timestamps"
+//	@Param			display_tz		query	[]string	false	"Display timezone"					collectionFormat(multi)
+//	@Param			lang			query	string		false	"Language of the description"		example(en,el,de)
 //	@Success		200
 //	@Failure		400
 //	@Failure		500
 //
-//	@Router			/ptlist [get]
+//	@Router			/v1/ptlist [get]
 func (t *TaskHandler) List() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		params, err := t.listParams(ctx, r)
+		if err != nil {
+			response.Error(w, err)
+			return
+		}
+
+		var payload interface{}
+
+		// verbose responses, the ones reporting suppressed timestamps or rendered in display timezones, are
+		// objects that also describe the period.
+		switch showSuppressed, _ := strconv.ParseBool(r.URL.Query().Get("show_suppressed")); {
+		case showSuppressed:
+			payload, err = t.schedule(ctx, params)
+		case len(params.DisplayZones) > 0:
+			payload, err = t.displayList(ctx, params)
+		default:
+			payload, err = t.useCase.GetList(ctx, params)
+		}
+
+		if err != nil {
+			t.logger.Error(ctx, err, "could not get matching task list")
+			response.Error(w, err)
+
+			return
+		}
+
+		response.ObserveOccurrences(w, occurrenceCount(payload))
+		response.Success(w, http.StatusOK, payload)
+	}
+}
+
+// ListV2 returns all matching timestamps of a periodic task as occurrence objects
+//
+//	@Summary		Returns all matching occurrences of a periodic task between 2 points in time, in RFC 3339 and rendered in the task and display timezones.
+//	@Tags			v2
+//	@Accept			json
+//	@Produce		json
+//	@Param			period			query	string		false	"Period"							example(1y,1mo,1d,1h)
+//	@Param			tz				query	string		false	"Timezone"							example(America/Los_Angeles)
+//	@Param			t1				query	string		false	"Start point"						example(20060102T150405Z)
+//	@Param			t2				query	string		false	"End point"							example(20060102T150405Z)
+//	@Param			blackout		query	[]string	false	"Blackout interval (t1/t2)"			collectionFormat(multi)
+//	@Param			blackout_set	query	[]string	false	"Named blackout set"				collectionFormat(multi)
+//	@Param			show_suppressed	query	bool		false	"Report suppressed occurrences"
+//	@Param			display_tz		query	[]string	false	"Display timezone"					collectionFormat(multi)
+//	@Param			lang			query	string		false	"Language of the description"		example(en,el,de)
+//	@Success		200	{object}	domain.OccurrenceList
+//	@Failure		400
+//	@Failure		500
+//
+//	@Router			/v2/ptlist [get]
+func (t *TaskHandler) ListV2() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		params, err := t.listParams(ctx, r)
+		if err != nil {
+			response.Error(w, err)
+			return
+		}
+
+		description, err := t.describe(ctx, params)
+		if err != nil {
+			t.logger.Error(ctx, err, "could not describe period")
+			response.Error(w, err)
+
+			return
+		}
+
+		schedule := &domain.PtSchedule{}
+
+		if showSuppressed, _ := strconv.ParseBool(r.URL.Query().Get("show_suppressed")); showSuppressed {
+			schedule, err = t.useCase.GetSchedule(ctx, params)
+		} else {
+			schedule.List, err = t.useCase.GetList(ctx, params)
+		}
+
+		if err != nil {
+			t.logger.Error(ctx, err, "could not get matching task list")
+			response.Error(w, err)
+
+			return
+		}
+
+		// occurrences are always rendered in the timezone of the task.
+		zones := append([]*time.Location{params.Timezone}, params.DisplayZones...)
+
+		occurrences, err := t.occurrences(ctx, schedule, zones)
+		if err != nil {
+			t.logger.Error(ctx, err, "could not render occurrences")
+			response.Error(w, err)
+
+			return
+		}
+
+		response.ObserveOccurrences(w, len(occurrences))
+		response.Success(w, http.StatusOK, &domain.OccurrenceList{Description: description, Occurrences: occurrences})
+	}
+}
+
+// listParams parses the query params shared by every version of the list route.
+func (t *TaskHandler) listParams(ctx context.Context, r *http.Request) (*utils.ListQueryParams, error) {
+	period := r.URL.Query().Get("period")
+	tz := r.URL.Query().Get("tz")
+	t1 := r.URL.Query().Get("t1")
+	t2 := r.URL.Query().Get("t2")
+
+	params, err := utils.GetListQueryParams(ctx, t.logger, period, tz, t1, t2)
+	blackouts, errB := utils.GetBlackouts(ctx, t.logger, r.URL.Query()["blackout"])
+	zones, errZ := utils.GetDisplayZones(ctx, t.logger, r.URL.Query()["display_tz"])
+
+	// report every invalid parameter at once.
+	if err = httperrors.Collect(err, errB, errZ); err != nil {
+		t.logger.Error(ctx, err, "could not parse query params")
+		return nil, err
+	}
+
+	params.Blackouts = blackouts
+	params.BlackoutSets = r.URL.Query()["blackout_set"]
+	params.DisplayZones = zones
+	params.Lang = strings.ToLower(r.URL.Query().Get("lang"))
+
+	if params.Lang == "" {
+		params.Lang = constants.LangEnglish
+	}
+
+	return params, nil
+}
+
+// describe describes the period of a list in its language, as GET /describe does. It runs before the list
+// is computed, so an unsupported language fails fast.
+func (t *TaskHandler) describe(ctx context.Context, params *utils.ListQueryParams) (string, error) {
+	description, err := t.useCase.Describe(ctx, &utils.DescribeParams{Period: params.Period, Timezone: params.Timezone, Lang: params.Lang})
+	if err != nil {
+		return "", err
+	}
+
+	return description.Description, nil
+}
+
+// displayList returns the matching timestamps rendered in the display timezones.
+func (t *TaskHandler) displayList(ctx context.Context, params *utils.ListQueryParams) (*domain.PtDisplayList, error) {
+	description, err := t.describe(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := t.useCase.GetList(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	occurrences, err := t.useCase.Display(ctx, list, params.DisplayZones)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PtDisplayList{List: occurrences, Description: description}, nil
+}
+
+// schedule returns the matching and suppressed timestamps, rendered in the display timezones when there are any.
+func (t *TaskHandler) schedule(ctx context.Context, params *utils.ListQueryParams) (interface{}, error) {
+	description, err := t.describe(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := t.useCase.GetSchedule(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(params.DisplayZones) == 0 {
+		schedule.Description = description
+
+		return schedule, nil
+	}
+
+	list, err := t.useCase.Display(ctx, schedule.List, params.DisplayZones)
+	if err != nil {
+		return nil, err
+	}
+
+	suppressed, err := t.useCase.Display(ctx, schedule.Suppressed, params.DisplayZones)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PtDisplaySchedule{List: list, Suppressed: suppressed, Description: description}, nil
+}
+
+// occurrenceCount returns the number of timestamps a list payload carries, suppressed ones included.
+func occurrenceCount(payload interface{}) int {
+	switch p := payload.(type) {
+	case domain.PtList:
+		return len(p)
+	case *domain.PtDisplayList:
+		return len(p.List)
+	case *domain.PtSchedule:
+		return len(p.List) + len(p.Suppressed)
+	case *domain.PtDisplaySchedule:
+		return len(p.List) + len(p.Suppressed)
+	}
+
+	return 0
+}
+
+// occurrences renders the matching and suppressed timestamps of a schedule in the zones, in chronological order.
+func (t *TaskHandler) occurrences(
+	ctx context.Context,
+	schedule *domain.PtSchedule,
+	zones []*time.Location,
+) ([]domain.Occurrence, error) {
+	list, err := t.useCase.Display(ctx, schedule.List, zones)
+	if err != nil {
+		return nil, err
+	}
+
+	var suppressed []domain.PtOccurrence
+
+	if len(schedule.Suppressed) > 0 {
+		suppressed, err = t.useCase.Display(ctx, schedule.Suppressed, zones)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return domain.Occurrences(list, suppressed)
+}
+
+// Compose returns the matching timestamps of an expression of periodic tasks
+//
+//	@Summary		Returns the matching timestamps of schedules combined with union, intersect and except.
+//	@Tags			v1
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body	object	true	"Expression"	example({"tz":"Europe/Athens","t1":"20210714T204603Z","t2":"20210715T123456Z","expr":{"op":"intersect","args":[{"period":"1h"},{"period":"6h"}]}})
+//	@Success		200
+//	@Failure		400
+//	@Failure		500
+//
+//	@Router			/v1/ptlist/compose [post]
+func (t *TaskHandler) Compose() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		params, err := utils.GetComposeParams(ctx, t.logger, r.Body)
+		if err != nil {
+			t.logger.Error(ctx, err, "could not parse compose request")
+			response.Error(w, err)
+
+			return
+		}
+
+		list, err := t.useCase.Compose(ctx, params)
+		if err != nil {
+			t.logger.Error(ctx, err, "could not compose task list")
+			response.Error(w, err)
+
+			return
+		}
+
+		response.ObserveOccurrences(w, len(list))
+		response.Success(w, http.StatusOK, list)
+	}
+}
+
+// Collisions reports the instants where many periodic tasks fire together
+//
+//	@Summary		Returns the instants where more than threshold tasks coincide, the peak concurrency, a histogram and offsets that reduce collisions.
+//	@Tags			v1
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body	object	true	"Schedules"	example({"tz":"Europe/Athens","t1":"20210714T204603Z","t2":"20210715T123456Z","threshold":1,"schedules":[{"name":"sync","period":"1h"},{"name":"backup","period":"6h"}]})
+//	@Success		200
+//	@Failure		400
+//	@Failure		500
+//
+//	@Router			/v1/ptlist/collisions [post]
+func (t *TaskHandler) Collisions() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		params, err := utils.GetCollisionParams(ctx, t.logger, r.Body)
+		if err != nil {
+			t.logger.Error(ctx, err, "could not parse collisions request")
+			response.Error(w, err)
+
+			return
+		}
+
+		report, err := t.useCase.Collisions(ctx, params)
+		if err != nil {
+			t.logger.Error(ctx, err, "could not analyze task collisions")
+			response.Error(w, err)
+
+			return
+		}
+
+		response.Success(w, http.StatusOK, report)
+	}
+}
+
+// Describe returns a human-readable description of a period
+//
+//	@Summary		Returns a localized, human-readable description of a period in a timezone.
+//	@Tags			v1
+//	@Accept			json
+//	@Produce		json
+//	@Param			period	query	string	false	"Period"	example(1y,1mo,1d,1h)
+//	@Param			tz		query	string	false	"Timezone"	example(Europe/Athens)
+//	@Param			lang	query	string	false	"Language"	Enums(en,el,de)
+//	@Success		200
+//	@Failure		400
+//	@Failure		500
+//
+//	@Router			/v1/describe [get]
+func (t *TaskHandler) Describe() func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
 		period := r.URL.Query().Get("period")
 		tz := r.URL.Query().Get("tz")
+		lang := r.URL.Query().Get("lang")
+
+		params, err := utils.GetDescribeQueryParams(ctx, t.logger, period, tz, lang)
+		if err != nil {
+			t.logger.Error(ctx, err, "could not parse query params")
+			response.Error(w, err)
+
+			return
+		}
+
+		description, err := t.useCase.Describe(ctx, params)
+		if err != nil {
+			t.logger.Error(ctx, err, "could not describe period")
+			response.Error(w, err)
+
+			return
+		}
+
+		response.Success(w, http.StatusOK, description)
+	}
+}
+
+// Parse turns a natural-language schedule into a period
+//
+//	@Summary		Parses a plain English schedule, such as "every 2 hours", and previews its next occurrences.
+//	@Tags			v1
+//	@Accept			json
+//	@Produce		json
+//	@Param			q		query	string	true	"Schedule"		example(every 2 hours)
+//	@Param			tz		query	string	false	"Timezone"		example(Europe/Athens)
+//	@Param			t1		query	string	false	"Start point"	example(20060102T150405Z)
+//	@Param			count	query	int		false	"Preview count"	example(5)
+//	@Param			lang	query	string	false	"Language"		example(en)
+//	@Success		200
+//	@Failure		400
+//	@Failure		500
+//
+//	@Router			/v1/parse [get]
+func (t *TaskHandler) Parse() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		q := r.URL.Query().Get("q")
+		tz := r.URL.Query().Get("tz")
+		t1 := r.URL.Query().Get("t1")
+		count := r.URL.Query().Get("count")
+		lang := r.URL.Query().Get("lang")
+
+		params, err := utils.GetParseQueryParams(ctx, t.logger, q, tz, t1, count, lang)
+		if err != nil {
+			t.logger.Error(ctx, err, "could not parse query params")
+			response.Error(w, err)
+
+			return
+		}
+
+		parsed, err := t.useCase.Parse(ctx, params)
+		if err != nil {
+			t.logger.Error(ctx, err, "could not parse schedule")
+
+			var parseErr *domain.ParseError
+			if errors.As(err, &parseErr) {
+				response.ErrorWithDetails(w, err, parseErr)
+
+				return
+			}
+
+			response.Error(w, err)
+
+			return
+		}
+
+		response.Success(w, http.StatusOK, parsed)
+	}
+}
+
+// Timezones returns the available timezones
+//
+//	@Summary		Returns the available timezones with their current offset and abbreviation.
+//	@Tags			v1
+//	@Accept			json
+//	@Produce		json
+//	@Param			search	query	string	false	"Case-insensitive name filter"	example(europe)
+//	@Success		200
+//	@Failure		500
+//
+//	@Router			/v1/timezones [get]
+func (t *TaskHandler) Timezones() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		timezones, err := t.useCase.Timezones(ctx, r.URL.Query().Get("search"))
+		if err != nil {
+			t.logger.Error(ctx, err, "could not list timezones")
+			response.Error(w, err)
+
+			return
+		}
+
+		response.Success(w, http.StatusOK, timezones)
+	}
+}
+
+// Transitions returns the offset changes of a timezone
+//
+//	@Summary		Returns every offset change of a timezone between 2 points in time.
+//	@Tags			v1
+//	@Accept			json
+//	@Produce		json
+//	@Param			name	path	string	true	"Timezone"		example(Europe/Athens)
+//	@Param			t1		query	string	false	"Start point"	example(20060102T150405Z)
+//	@Param			t2		query	string	false	"End point"		example(20060102T150405Z)
+//	@Success		200
+//	@Failure		400
+//	@Failure		500
+//
+//	@Router			/v1/timezones/{name}/transitions [get]
+func (t *TaskHandler) Transitions() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		name := mux.Vars(r)["name"]
 		t1 := r.URL.Query().Get("t1")
 		t2 := r.URL.Query().Get("t2")
 
-		params, err := utils.GetListQueryParams(ctx, t.logger, period, tz, t1, t2)
+		params, err := utils.GetTransitionsQueryParams(ctx, t.logger, name, t1, t2)
 		if err != nil {
 			t.logger.Error(ctx, err, "could not parse query params")
 			response.Error(w, err)
@@ -52,12 +511,14 @@ func (t *TaskHandler) List() func(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		list, err := t.useCase.GetList(ctx, params)
+		transitions, err := t.useCase.Transitions(ctx, params)
 		if err != nil {
-			t.logger.Error(ctx, err, "could not get matching task list")
+			t.logger.Error(ctx, err, "could not list timezone transitions")
 			response.Error(w, err)
+
+			return
 		}
 
-		response.Success(w, http.StatusOK, list)
+		response.Success(w, http.StatusOK, transitions)
 	}
 }
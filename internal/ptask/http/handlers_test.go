@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -20,7 +21,10 @@ import (
 	"github.com/KarolosLykos/ptask/internal/constants"
 	"github.com/KarolosLykos/ptask/internal/logger"
 	"github.com/KarolosLykos/ptask/internal/logger/log"
+	"github.com/KarolosLykos/ptask/internal/ptask/domain"
 	mock_ptask "github.com/KarolosLykos/ptask/internal/ptask/mock"
+	"github.com/KarolosLykos/ptask/internal/utils"
+	"github.com/KarolosLykos/ptask/internal/utils/httperrors"
 	"github.com/KarolosLykos/ptask/internal/utils/response"
 )
 
@@ -36,6 +40,7 @@ func TestTaskHandler_List(t *testing.T) {
 		statusCode  int
 		status      string
 		list        []string
+		description string
 	}{
 		{name: "post not allowed", useCaseStub: func(uc *mock_ptask.MockUseCase) {}, method: http.MethodPost, statusCode: http.StatusMethodNotAllowed},
 		{name: "put not allowed", useCaseStub: func(uc *mock_ptask.MockUseCase) {}, method: http.MethodPut, statusCode: http.StatusMethodNotAllowed},
@@ -47,6 +52,59 @@ func TestTaskHandler_List(t *testing.T) {
 			statusCode:  http.StatusBadRequest,
 			status:      constants.StatusError,
 		},
+		{
+			name:        "invalid blackout",
+			useCaseStub: func(uc *mock_ptask.MockUseCase) {},
+			method:      http.MethodGet,
+			params:      map[string]string{"period": "1d", "tz": "Europe/Athens", "t1": "20210728T204603Z", "t2": "20210802T123456Z", "blackout": "wrong"},
+			statusCode:  http.StatusBadRequest,
+			status:      constants.StatusError,
+		},
+		{
+			name: "show suppressed",
+			useCaseStub: func(uc *mock_ptask.MockUseCase) {
+				expectDescribe(uc, "de", "Jeden Tag um 00:00 (Europe/Athens)")
+				uc.EXPECT().GetSchedule(gomock.Any(), gomock.Any()).Times(1).
+					Return(&domain.PtSchedule{List: domain.PtList{"20210728T210000Z"}, Suppressed: domain.PtList{"20210729T210000Z"}}, nil)
+			},
+			method:      http.MethodGet,
+			params:      map[string]string{"period": "1d", "tz": "Europe/Athens", "t1": "20210728T204603Z", "t2": "20210730T123456Z", "blackout": "20210729T000000Z/20210730T000000Z", "show_suppressed": "true", "lang": "DE"},
+			statusCode:  http.StatusOK,
+			status:      constants.StatusSuccess,
+			description: "Jeden Tag um 00:00 (Europe/Athens)",
+		},
+		{
+			name: "unsupported language",
+			useCaseStub: func(uc *mock_ptask.MockUseCase) {
+				expectDescribe(uc, "en", "")
+			},
+			method:     http.MethodGet,
+			params:     map[string]string{"period": "1d", "tz": "Europe/Athens", "t1": "20210728T204603Z", "t2": "20210730T123456Z", "display_tz": "Asia/Tokyo", "lang": "fr"},
+			statusCode: http.StatusBadRequest,
+			status:     constants.StatusError,
+		},
+		{
+			name:        "invalid display timezone",
+			useCaseStub: func(uc *mock_ptask.MockUseCase) {},
+			method:      http.MethodGet,
+			params:      map[string]string{"period": "1d", "tz": "Europe/Athens", "t1": "20210728T204603Z", "t2": "20210802T123456Z", "display_tz": "Asia/Nowhere"},
+			statusCode:  http.StatusBadRequest,
+			status:      constants.StatusError,
+		},
+		{
+			name: "display timezones",
+			useCaseStub: func(uc *mock_ptask.MockUseCase) {
+				expectDescribe(uc, "en", "Every day at 00:00 (Europe/Athens)")
+				uc.EXPECT().GetList(gomock.Any(), gomock.Any()).Times(1).Return(domain.PtList{"20210728T210000Z"}, nil)
+				uc.EXPECT().Display(gomock.Any(), domain.PtList{"20210728T210000Z"}, gomock.Len(1)).Times(1).
+					Return([]domain.PtOccurrence{{Timestamp: "20210728T210000Z", Local: map[string]string{"Asia/Tokyo": "2021-07-29T06:00:00+09:00"}}}, nil)
+			},
+			method:      http.MethodGet,
+			params:      map[string]string{"period": "1d", "tz": "Europe/Athens", "t1": "20210728T204603Z", "t2": "20210729T123456Z", "display_tz": "Asia/Tokyo"},
+			statusCode:  http.StatusOK,
+			status:      constants.StatusSuccess,
+			description: "Every day at 00:00 (Europe/Athens)",
+		},
 		{
 			name: "useCase error",
 			useCaseStub: func(uc *mock_ptask.MockUseCase) {
@@ -122,11 +180,523 @@ func TestTaskHandler_List(t *testing.T) {
 
 					assert.ElementsMatch(t, list, tc.list)
 				}
+
+				if tc.description != "" {
+					data, ok := resp.Data.(map[string]interface{})
+					require.True(t, ok)
+
+					assert.Equal(t, tc.description, data["description"])
+				}
+			}
+		})
+	}
+}
+
+func TestTaskHandler_ListV2(t *testing.T) {
+	ctx := context.Background()
+	l := getLogger()
+
+	tt := []struct {
+		name        string
+		useCaseStub func(uc *mock_ptask.MockUseCase)
+		query       string
+		statusCode  int
+		data        string
+		invalid     []string
+	}{
+		{
+			name:        "invalid params",
+			useCaseStub: func(uc *mock_ptask.MockUseCase) {},
+			query:       "period=wrong&tz=Europe/Athens&t1=20210728T204603Z&t2=20210802T123456Z&display_tz=Asia/Nowhere",
+			statusCode:  http.StatusBadRequest,
+			invalid:     []string{"period", "display_tz"},
+		},
+		{
+			name: "useCase error",
+			useCaseStub: func(uc *mock_ptask.MockUseCase) {
+				expectDescribe(uc, "en", "Every day at 00:00 (Europe/Athens)")
+				uc.EXPECT().GetList(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("something went wrong"))
+			},
+			query:      "period=1d&tz=Europe/Athens&t1=20210728T204603Z&t2=20210802T123456Z",
+			statusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "ok",
+			useCaseStub: func(uc *mock_ptask.MockUseCase) {
+				expectDescribe(uc, "en", "Every day at 00:00 (Europe/Athens)")
+				uc.EXPECT().GetList(gomock.Any(), gomock.Any()).Times(1).Return(domain.PtList{"20210728T210000Z"}, nil)
+				uc.EXPECT().Display(gomock.Any(), domain.PtList{"20210728T210000Z"}, gomock.Len(2)).Times(1).
+					Return([]domain.PtOccurrence{{Timestamp: "20210728T210000Z", Local: map[string]string{"Europe/Athens": "2021-07-29T00:00:00+03:00", "Asia/Tokyo": "2021-07-29T06:00:00+09:00"}}}, nil)
+			},
+			query:      "period=1d&tz=Europe/Athens&t1=20210728T204603Z&t2=20210729T123456Z&display_tz=Asia/Tokyo",
+			statusCode: http.StatusOK,
+			data: `{"description":"Every day at 00:00 (Europe/Athens)","occurrences":[` +
+				`{"timestamp":"2021-07-28T21:00:00Z","local":{"Europe/Athens":"2021-07-29T00:00:00+03:00","Asia/Tokyo":"2021-07-29T06:00:00+09:00"},"suppressed":false}]}`,
+		},
+		{
+			name: "show suppressed",
+			useCaseStub: func(uc *mock_ptask.MockUseCase) {
+				expectDescribe(uc, "el", "Κάθε μέρα στις 00:00 (Europe/Athens)")
+				uc.EXPECT().GetSchedule(gomock.Any(), gomock.Any()).Times(1).
+					Return(&domain.PtSchedule{List: domain.PtList{"20210728T210000Z"}, Suppressed: domain.PtList{"20210729T210000Z"}}, nil)
+				uc.EXPECT().Display(gomock.Any(), domain.PtList{"20210728T210000Z"}, gomock.Len(1)).Times(1).
+					Return([]domain.PtOccurrence{{Timestamp: "20210728T210000Z", Local: map[string]string{"Europe/Athens": "2021-07-29T00:00:00+03:00"}}}, nil)
+				uc.EXPECT().Display(gomock.Any(), domain.PtList{"20210729T210000Z"}, gomock.Len(1)).Times(1).
+					Return([]domain.PtOccurrence{{Timestamp: "20210729T210000Z", Local: map[string]string{"Europe/Athens": "2021-07-30T00:00:00+03:00"}}}, nil)
+			},
+			query:      "period=1d&tz=Europe/Athens&t1=20210728T204603Z&t2=20210730T123456Z&blackout=20210729T000000Z/20210730T000000Z&show_suppressed=true&lang=el",
+			statusCode: http.StatusOK,
+			data: `{"description":"Κάθε μέρα στις 00:00 (Europe/Athens)","occurrences":[` +
+				`{"timestamp":"2021-07-28T21:00:00Z","local":{"Europe/Athens":"2021-07-29T00:00:00+03:00"},"suppressed":false},` +
+				`{"timestamp":"2021-07-29T21:00:00Z","local":{"Europe/Athens":"2021-07-30T00:00:00+03:00"},"suppressed":true}]}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			useCase := mock_ptask.NewMockUseCase(ctrl)
+
+			tc.useCaseStub(useCase)
+
+			srv := httptest.NewServer(Routes(mux.NewRouter(), NewTaskHandler(l, useCase)))
+			defer srv.Close()
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/v2/ptlist?"+tc.query, nil)
+			require.NoError(t, err)
+
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+
+			// errors of v2 are problem details listing every invalid param.
+			if tc.invalid != nil {
+				assert.Equal(t, constants.ProblemContentType, res.Header.Get("Content-Type"))
+
+				problem := &response.Problem{}
+				require.NoError(t, json.NewDecoder(res.Body).Decode(problem))
+
+				params := make([]string, 0, len(problem.Errors))
+				for _, e := range problem.Errors {
+					params = append(params, e.Param)
+				}
+
+				assert.Equal(t, tc.invalid, params)
+
+				return
+			}
+
+			if tc.data == "" {
+				return
+			}
+
+			resp := &struct {
+				Data json.RawMessage `json:"data"`
+			}{}
+			require.NoError(t, json.NewDecoder(res.Body).Decode(resp))
+
+			assert.JSONEq(t, tc.data, string(resp.Data))
+		})
+	}
+}
+
+func TestRoutes(t *testing.T) {
+	ctx := context.Background()
+	l := getLogger()
+
+	tt := []struct {
+		name       string
+		path       string
+		deprecated bool
+		successor  string
+	}{
+		{name: "v1", path: "/v1/timezones"},
+		{name: "unversioned alias", path: "/timezones", deprecated: true, successor: `</v1/timezones>; rel="successor-version"`},
+		{
+			name:       "unversioned alias with path variables",
+			path:       "/timezones/Europe/Athens/transitions?t1=20210101T000000Z&t2=20220101T000000Z",
+			deprecated: true,
+			successor:  `</v1/timezones/Europe/Athens/transitions>; rel="successor-version"`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			useCase := mock_ptask.NewMockUseCase(ctrl)
+			useCase.EXPECT().Timezones(gomock.Any(), gomock.Any()).AnyTimes().Return([]domain.PtTimezone{}, nil)
+			useCase.EXPECT().Transitions(gomock.Any(), gomock.Any()).AnyTimes().Return([]domain.PtTransition{}, nil)
+
+			srv := httptest.NewServer(Routes(mux.NewRouter(), NewTaskHandler(l, useCase)))
+			defer srv.Close()
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+tc.path, nil)
+			require.NoError(t, err)
+
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+
+			defer res.Body.Close()
+
+			assert.Equal(t, http.StatusOK, res.StatusCode)
+
+			if !tc.deprecated {
+				assert.Empty(t, res.Header.Get(constants.DeprecationHeader))
+				assert.Empty(t, res.Header.Get(constants.SunsetHeader))
+
+				return
 			}
+
+			assert.Equal(t, "@1793491200", res.Header.Get(constants.DeprecationHeader))
+			assert.Equal(t, "Sat, 01 May 2027 00:00:00 GMT", res.Header.Get(constants.SunsetHeader))
+			assert.Equal(t, tc.successor, res.Header.Get(constants.LinkHeader))
 		})
 	}
 }
 
+func TestTaskHandler_Compose(t *testing.T) {
+	ctx := context.Background()
+	l := getLogger()
+
+	body := `{"tz": "Europe/Athens", "t1": "20210714T204603Z", "t2": "20210715T123456Z", "expr": {"op": "intersect", "args": [{"period": "1h"}, {"period": "6h"}]}}`
+
+	tt := []struct {
+		name        string
+		useCaseStub func(uc *mock_ptask.MockUseCase)
+		method      string
+		body        string
+		statusCode  int
+		list        []string
+	}{
+		{name: "get not allowed", useCaseStub: func(uc *mock_ptask.MockUseCase) {}, method: http.MethodGet, statusCode: http.StatusMethodNotAllowed},
+		{name: "invalid body", useCaseStub: func(uc *mock_ptask.MockUseCase) {}, method: http.MethodPost, body: `{"expr": {"op": "xor"}}`, statusCode: http.StatusBadRequest},
+		{
+			name: "useCase error",
+			useCaseStub: func(uc *mock_ptask.MockUseCase) {
+				uc.EXPECT().Compose(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("something went wrong"))
+			},
+			method:     http.MethodPost,
+			body:       body,
+			statusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "ok",
+			useCaseStub: func(uc *mock_ptask.MockUseCase) {
+				uc.EXPECT().Compose(gomock.Any(), gomock.Any()).Times(1).
+					Return(domain.PtList{"20210714T210000Z", "20210715T030000Z", "20210715T090000Z"}, nil)
+			},
+			method:     http.MethodPost,
+			body:       body,
+			statusCode: http.StatusOK,
+			list:       []string{"20210714T210000Z", "20210715T030000Z", "20210715T090000Z"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			useCase := mock_ptask.NewMockUseCase(ctrl)
+
+			tc.useCaseStub(useCase)
+
+			router := Routes(mux.NewRouter(), NewTaskHandler(l, useCase))
+
+			srv := httptest.NewServer(router)
+			defer srv.Close()
+
+			req, err := http.NewRequestWithContext(ctx, tc.method, fmt.Sprintf("%s/ptlist/compose", srv.URL), strings.NewReader(tc.body))
+			require.NoError(t, err)
+
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+
+			if tc.list != nil {
+				resp := &response.Response{}
+				require.NoError(t, json.NewDecoder(res.Body).Decode(resp))
+
+				list, ok := resp.Data.([]interface{})
+				require.True(t, ok)
+				assert.ElementsMatch(t, list, tc.list)
+			}
+		})
+	}
+}
+
+func TestTaskHandler_Collisions(t *testing.T) {
+	ctx := context.Background()
+	l := getLogger()
+
+	body := `{"tz": "Europe/Athens", "t1": "20210714T204603Z", "t2": "20210715T123456Z", "schedules": [{"name": "sync", "period": "1h"}, {"name": "backup", "period": "6h"}]}`
+
+	tt := []struct {
+		name        string
+		useCaseStub func(uc *mock_ptask.MockUseCase)
+		body        string
+		statusCode  int
+	}{
+		{name: "invalid body", useCaseStub: func(uc *mock_ptask.MockUseCase) {}, body: `{"schedules": []}`, statusCode: http.StatusBadRequest},
+		{
+			name: "useCase error",
+			useCaseStub: func(uc *mock_ptask.MockUseCase) {
+				uc.EXPECT().Collisions(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("something went wrong"))
+			},
+			body:       body,
+			statusCode: http.StatusInternalServerError,
+		},
+		{
+			name: "ok",
+			useCaseStub: func(uc *mock_ptask.MockUseCase) {
+				uc.EXPECT().Collisions(gomock.Any(), gomock.Any()).Times(1).Return(&domain.CollisionReport{Peak: 2}, nil)
+			},
+			body:       body,
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			useCase := mock_ptask.NewMockUseCase(ctrl)
+
+			tc.useCaseStub(useCase)
+
+			srv := httptest.NewServer(Routes(mux.NewRouter(), NewTaskHandler(l, useCase)))
+			defer srv.Close()
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/ptlist/collisions", srv.URL), strings.NewReader(tc.body))
+			require.NoError(t, err)
+
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
+
+func TestTaskHandler_Describe(t *testing.T) {
+	ctx := context.Background()
+	l := getLogger()
+
+	tt := []struct {
+		name        string
+		useCaseStub func(uc *mock_ptask.MockUseCase)
+		query       string
+		statusCode  int
+	}{
+		{name: "invalid period", useCaseStub: func(uc *mock_ptask.MockUseCase) {}, query: "period=1w", statusCode: http.StatusBadRequest},
+		{
+			name: "unsupported language",
+			useCaseStub: func(uc *mock_ptask.MockUseCase) {
+				uc.EXPECT().Describe(gomock.Any(), gomock.Any()).Times(1).Return(nil, httperrors.ErrUnsupportedLanguage)
+			},
+			query:      "period=1mo&tz=Europe/Athens&lang=fr",
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name: "ok",
+			useCaseStub: func(uc *mock_ptask.MockUseCase) {
+				uc.EXPECT().Describe(gomock.Any(), gomock.Any()).Times(1).
+					Return(&domain.PtDescription{Description: "Every month on the 1st at 00:00 (Europe/Athens)"}, nil)
+			},
+			query:      "period=1mo&tz=Europe/Athens",
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			useCase := mock_ptask.NewMockUseCase(ctrl)
+
+			tc.useCaseStub(useCase)
+
+			srv := httptest.NewServer(Routes(mux.NewRouter(), NewTaskHandler(l, useCase)))
+			defer srv.Close()
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/describe?%s", srv.URL, tc.query), nil)
+			require.NoError(t, err)
+
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
+
+func TestTaskHandler_Parse(t *testing.T) {
+	ctx := context.Background()
+	l := getLogger()
+
+	tt := []struct {
+		name        string
+		useCaseStub func(uc *mock_ptask.MockUseCase)
+		query       string
+		statusCode  int
+		details     bool
+	}{
+		{name: "invalid count", useCaseStub: func(uc *mock_ptask.MockUseCase) {}, query: "q=daily&count=wrong", statusCode: http.StatusBadRequest},
+		{
+			name: "unparsable schedule",
+			useCaseStub: func(uc *mock_ptask.MockUseCase) {
+				uc.EXPECT().Parse(gomock.Any(), gomock.Any()).Times(1).
+					Return(nil, &domain.ParseError{Input: "every weekday", Token: "weekday", Position: 1, Reason: "weekday rules are not supported"})
+			},
+			query:      "q=every+weekday",
+			statusCode: http.StatusBadRequest,
+			details:    true,
+		},
+		{
+			name: "ok",
+			useCaseStub: func(uc *mock_ptask.MockUseCase) {
+				uc.EXPECT().Parse(gomock.Any(), gomock.Any()).Times(1).Return(&domain.PtParse{Period: "2h"}, nil)
+			},
+			query:      "q=every+2+hours&tz=Europe/Athens",
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			useCase := mock_ptask.NewMockUseCase(ctrl)
+
+			tc.useCaseStub(useCase)
+
+			srv := httptest.NewServer(Routes(mux.NewRouter(), NewTaskHandler(l, useCase)))
+			defer srv.Close()
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/parse?%s", srv.URL, tc.query), nil)
+			require.NoError(t, err)
+
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+
+			if tc.details {
+				resp := &response.Response{}
+				require.NoError(t, json.NewDecoder(res.Body).Decode(resp))
+
+				details, ok := resp.Data.(map[string]interface{})
+				require.True(t, ok)
+				assert.Equal(t, "weekday", details["token"])
+			}
+		})
+	}
+}
+
+func TestTaskHandler_Timezones(t *testing.T) {
+	ctx := context.Background()
+	l := getLogger()
+
+	tt := []struct {
+		name        string
+		useCaseStub func(uc *mock_ptask.MockUseCase)
+		path        string
+		statusCode  int
+	}{
+		{
+			name: "timezones",
+			useCaseStub: func(uc *mock_ptask.MockUseCase) {
+				uc.EXPECT().Timezones(gomock.Any(), "athens").Times(1).Return([]domain.PtTimezone{{Name: "Europe/Athens"}}, nil)
+			},
+			path:       "/timezones?search=athens",
+			statusCode: http.StatusOK,
+		},
+		{
+			name: "timezones error",
+			useCaseStub: func(uc *mock_ptask.MockUseCase) {
+				uc.EXPECT().Timezones(gomock.Any(), "").Times(1).Return(nil, errors.New("no zoneinfo database found"))
+			},
+			path:       "/timezones",
+			statusCode: http.StatusInternalServerError,
+		},
+		{
+			name:        "transitions invalid timezone",
+			useCaseStub: func(uc *mock_ptask.MockUseCase) {},
+			path:        "/timezones/Europe/Wrong/transitions?t1=20210101T000000Z&t2=20220101T000000Z",
+			statusCode:  http.StatusBadRequest,
+		},
+		{
+			name: "transitions",
+			useCaseStub: func(uc *mock_ptask.MockUseCase) {
+				uc.EXPECT().Transitions(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ context.Context, params *utils.TransitionParams) ([]domain.PtTransition, error) {
+						assert.Equal(t, "America/Argentina/Buenos_Aires", params.Timezone.String())
+
+						return []domain.PtTransition{}, nil
+					})
+			},
+			path:       "/timezones/America/Argentina/Buenos_Aires/transitions?t1=20210101T000000Z&t2=20220101T000000Z",
+			statusCode: http.StatusOK,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			useCase := mock_ptask.NewMockUseCase(ctrl)
+
+			tc.useCaseStub(useCase)
+
+			srv := httptest.NewServer(Routes(mux.NewRouter(), NewTaskHandler(l, useCase)))
+			defer srv.Close()
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+tc.path, nil)
+			require.NoError(t, err)
+
+			res, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+
+			defer res.Body.Close()
+
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
+
+// expectDescribe expects the period of a list to be described once, answering as the use case does for
+// languages other than lang.
+func expectDescribe(uc *mock_ptask.MockUseCase, lang, description string) {
+	uc.EXPECT().Describe(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, params *utils.DescribeParams) (*domain.PtDescription, error) {
+			if params.Lang != lang {
+				return nil, httperrors.Wrapf(httperrors.ErrUnsupportedLanguage, "%s", params.Lang)
+			}
+
+			return &domain.PtDescription{Description: description}, nil
+		})
+}
+
 func getLogger() logger.Logger {
 	l := &logrus.Logger{
 		Out:          io.Discard,
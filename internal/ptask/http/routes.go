@@ -1,15 +1,78 @@
 package http
 
 import (
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
 
+	"github.com/KarolosLykos/ptask/internal/constants"
 	"github.com/KarolosLykos/ptask/internal/ptask"
+	"github.com/KarolosLykos/ptask/internal/utils/response"
 )
 
+var (
+	// deprecatedAt is when the unversioned routes were deprecated in favor of /v1.
+	deprecatedAt = time.Date(2026, time.November, 1, 0, 0, 0, 0, time.UTC)
+	// sunsetAt is when the unversioned routes stop being served.
+	sunsetAt = time.Date(2027, time.May, 1, 0, 0, 0, 0, time.UTC)
+)
+
+type route struct {
+	path    string
+	method  string
+	handler http.HandlerFunc
+}
+
+// Routes registers the routes of every version under its prefix. The unversioned routes are kept as
+// deprecated aliases of /v1. Errors are problem details on /v2, and on /v1 when the request accepts them.
 func Routes(router *mux.Router, taskHandler ptask.Handlers) *mux.Router {
-	router.HandleFunc("/ptlist", taskHandler.List()).Methods(http.MethodGet)
+	v1 := router.PathPrefix("/v1").Subrouter()
+	v1.Use(response.NegotiateProblems)
+
+	for _, rt := range v1Routes(taskHandler) {
+		v1.HandleFunc(rt.path, rt.handler).Methods(rt.method)
+		router.Handle(rt.path, response.NegotiateProblems(deprecated("/v1", rt.handler))).Methods(rt.method)
+	}
+
+	v2 := router.PathPrefix("/v2").Subrouter()
+	v2.Use(response.Problems)
+
+	for _, rt := range v2Routes(taskHandler) {
+		v2.HandleFunc(rt.path, rt.handler).Methods(rt.method)
+	}
 
 	return router
 }
+
+func v1Routes(taskHandler ptask.Handlers) []route {
+	return []route{
+		{path: "/ptlist", method: http.MethodGet, handler: taskHandler.List()},
+		{path: "/ptlist/compose", method: http.MethodPost, handler: taskHandler.Compose()},
+		{path: "/ptlist/collisions", method: http.MethodPost, handler: taskHandler.Collisions()},
+		{path: "/describe", method: http.MethodGet, handler: taskHandler.Describe()},
+		{path: "/parse", method: http.MethodGet, handler: taskHandler.Parse()},
+		{path: "/timezones", method: http.MethodGet, handler: taskHandler.Timezones()},
+		{path: "/timezones/{name:.+}/transitions", method: http.MethodGet, handler: taskHandler.Transitions()},
+	}
+}
+
+// v2Routes holds the routes whose response changed since v1.
+func v2Routes(taskHandler ptask.Handlers) []route {
+	return []route{
+		{path: "/ptlist", method: http.MethodGet, handler: taskHandler.ListV2()},
+	}
+}
+
+// deprecated signals the deprecation (RFC 9745) and the sunset (RFC 8594) of an unversioned route, linking
+// to the same route under the successor prefix.
+func deprecated(successor string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(constants.DeprecationHeader, fmt.Sprintf("@%d", deprecatedAt.Unix()))
+		w.Header().Set(constants.SunsetHeader, sunsetAt.Format(http.TimeFormat))
+		w.Header().Add(constants.LinkHeader, fmt.Sprintf(`<%s%s>; rel="successor-version"`, successor, r.URL.EscapedPath()))
+
+		next(w, r)
+	}
+}
@@ -7,6 +7,7 @@ package mock_ptask
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/KarolosLykos/ptask/internal/ptask/domain"
 	utils "github.com/KarolosLykos/ptask/internal/utils"
@@ -36,6 +37,80 @@ func (m *MockUseCase) EXPECT() *MockUseCaseMockRecorder {
 	return m.recorder
 }
 
+// Collisions mocks base method.
+func (m *MockUseCase) Collisions(ctx context.Context, params *utils.CollisionParams) (*domain.CollisionReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Collisions", ctx, params)
+	ret0, _ := ret[0].(*domain.CollisionReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Collisions indicates an expected call of Collisions.
+func (mr *MockUseCaseMockRecorder) Collisions(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Collisions", reflect.TypeOf((*MockUseCase)(nil).Collisions), ctx, params)
+}
+
+// Compose mocks base method.
+func (m *MockUseCase) Compose(ctx context.Context, params *utils.ComposeParams) (domain.PtList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Compose", ctx, params)
+	ret0, _ := ret[0].(domain.PtList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Compose indicates an expected call of Compose.
+func (mr *MockUseCaseMockRecorder) Compose(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compose", reflect.TypeOf((*MockUseCase)(nil).Compose), ctx, params)
+}
+
+// Describe mocks base method.
+func (m *MockUseCase) Describe(ctx context.Context, params *utils.DescribeParams) (*domain.PtDescription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Describe", ctx, params)
+	ret0, _ := ret[0].(*domain.PtDescription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Describe indicates an expected call of Describe.
+func (mr *MockUseCaseMockRecorder) Describe(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Describe", reflect.TypeOf((*MockUseCase)(nil).Describe), ctx, params)
+}
+
+// Display mocks base method.
+func (m *MockUseCase) Display(ctx context.Context, list domain.PtList, zones []*time.Location) ([]domain.PtOccurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Display", ctx, list, zones)
+	ret0, _ := ret[0].([]domain.PtOccurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Display indicates an expected call of Display.
+func (mr *MockUseCaseMockRecorder) Display(ctx, list, zones interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Display", reflect.TypeOf((*MockUseCase)(nil).Display), ctx, list, zones)
+}
+
+// Each mocks base method.
+func (m *MockUseCase) Each(ctx context.Context, params *utils.ListQueryParams, fn func(string) bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Each", ctx, params, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Each indicates an expected call of Each.
+func (mr *MockUseCaseMockRecorder) Each(ctx, params, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Each", reflect.TypeOf((*MockUseCase)(nil).Each), ctx, params, fn)
+}
+
 // GetList mocks base method.
 func (m *MockUseCase) GetList(ctx context.Context, params *utils.ListQueryParams) (domain.PtList, error) {
 	m.ctrl.T.Helper()
@@ -50,3 +125,63 @@ func (mr *MockUseCaseMockRecorder) GetList(ctx, params interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockUseCase)(nil).GetList), ctx, params)
 }
+
+// GetSchedule mocks base method.
+func (m *MockUseCase) GetSchedule(ctx context.Context, params *utils.ListQueryParams) (*domain.PtSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedule", ctx, params)
+	ret0, _ := ret[0].(*domain.PtSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedule indicates an expected call of GetSchedule.
+func (mr *MockUseCaseMockRecorder) GetSchedule(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedule", reflect.TypeOf((*MockUseCase)(nil).GetSchedule), ctx, params)
+}
+
+// Parse mocks base method.
+func (m *MockUseCase) Parse(ctx context.Context, params *utils.ParseParams) (*domain.PtParse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Parse", ctx, params)
+	ret0, _ := ret[0].(*domain.PtParse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Parse indicates an expected call of Parse.
+func (mr *MockUseCaseMockRecorder) Parse(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Parse", reflect.TypeOf((*MockUseCase)(nil).Parse), ctx, params)
+}
+
+// Timezones mocks base method.
+func (m *MockUseCase) Timezones(ctx context.Context, search string) ([]domain.PtTimezone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Timezones", ctx, search)
+	ret0, _ := ret[0].([]domain.PtTimezone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Timezones indicates an expected call of Timezones.
+func (mr *MockUseCaseMockRecorder) Timezones(ctx, search interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Timezones", reflect.TypeOf((*MockUseCase)(nil).Timezones), ctx, search)
+}
+
+// Transitions mocks base method.
+func (m *MockUseCase) Transitions(ctx context.Context, params *utils.TransitionParams) ([]domain.PtTransition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transitions", ctx, params)
+	ret0, _ := ret[0].([]domain.PtTransition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Transitions indicates an expected call of Transitions.
+func (mr *MockUseCaseMockRecorder) Transitions(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transitions", reflect.TypeOf((*MockUseCase)(nil).Transitions), ctx, params)
+}
@@ -0,0 +1,60 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/KarolosLykos/ptask/internal/utils/httperrors"
+)
+
+// Status converts an error of the use case to a gRPC status error with the message of its sentinel. The
+// invalid parameters of a ValidationError are attached as a BadRequest detail.
+func Status(err error) error {
+	s := status.New(Code(err), httperrors.Message(err))
+
+	var v *httperrors.ValidationError
+	if !errors.As(err, &v) {
+		return s.Err()
+	}
+
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(v.Fields))
+	for _, field := range v.Fields {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: field.Param, Description: field.Reason})
+	}
+
+	if detailed, err := s.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); err == nil {
+		s = detailed
+	}
+
+	return s.Err()
+}
+
+// Code maps the HTTP status an error of the use case is answered with to a gRPC status code.
+func Code(err error) codes.Code {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	}
+
+	switch httperrors.From(err).Status {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusRequestEntityTooLarge:
+		return codes.ResourceExhausted
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	default:
+		return codes.Internal
+	}
+}
@@ -0,0 +1,142 @@
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/KarolosLykos/ptask/internal/constants"
+	"github.com/KarolosLykos/ptask/internal/logger"
+	"github.com/KarolosLykos/ptask/internal/ptask"
+	"github.com/KarolosLykos/ptask/internal/utils"
+	"github.com/KarolosLykos/ptask/internal/utils/httperrors"
+	"github.com/KarolosLykos/ptask/pkg/ptaskpb"
+)
+
+// TaskService serves the periodic task use case over gRPC.
+type TaskService struct {
+	ptaskpb.UnimplementedPeriodicTaskServiceServer
+
+	logger  logger.Logger
+	useCase ptask.UseCase
+}
+
+func NewTaskService(logger logger.Logger, useCase ptask.UseCase) *TaskService {
+	return &TaskService{
+		logger:  logger,
+		useCase: useCase,
+	}
+}
+
+// List returns all matching timestamps of a periodic task.
+func (t *TaskService) List(ctx context.Context, req *ptaskpb.ListRequest) (*ptaskpb.ListResponse, error) {
+	params, err := t.params(ctx, req)
+	if err != nil {
+		t.logger.Error(ctx, err, "could not parse request")
+		return nil, Status(err)
+	}
+
+	list, err := t.useCase.GetList(ctx, params)
+	if err != nil {
+		t.logger.Error(ctx, err, "could not get matching task list")
+		return nil, Status(err)
+	}
+
+	return &ptaskpb.ListResponse{Timestamps: list}, nil
+}
+
+// Stream sends the matching timestamps of a periodic task one at a time.
+func (t *TaskService) Stream(req *ptaskpb.ListRequest, stream ptaskpb.PeriodicTaskService_StreamServer) error {
+	ctx := stream.Context()
+
+	params, err := t.params(ctx, req)
+	if err != nil {
+		t.logger.Error(ctx, err, "could not parse request")
+		return Status(err)
+	}
+
+	// each occurrence is sent as soon as it is computed, stopping at the first failed send.
+	var sendErr error
+
+	err = t.useCase.Each(ctx, params, func(timestamp string) bool {
+		if sendErr = ctx.Err(); sendErr != nil {
+			sendErr = Status(sendErr)
+			return false
+		}
+
+		sendErr = stream.Send(&ptaskpb.Occurrence{Timestamp: timestamp})
+
+		return sendErr == nil
+	})
+	if err != nil {
+		t.logger.Error(ctx, err, "could not get matching task list")
+		return Status(err)
+	}
+
+	return sendErr
+}
+
+// Match sends, for every timestamp of the request, whether it is an invocation of the periodic task.
+func (t *TaskService) Match(req *ptaskpb.MatchRequest, stream ptaskpb.PeriodicTaskService_MatchServer) error {
+	ctx := stream.Context()
+
+	params, err := t.params(ctx, req.GetTask())
+	if err != nil {
+		t.logger.Error(ctx, err, "could not parse request")
+		return Status(err)
+	}
+
+	// normalize the timestamps so they compare with the ones of the schedule.
+	timestamps := make([]string, 0, len(req.GetTimestamps()))
+
+	for _, timestamp := range req.GetTimestamps() {
+		point, err := time.Parse(constants.TimestampLayout, timestamp)
+		if err != nil {
+			err = httperrors.Wrap(httperrors.ErrInvalidTimestamp, err)
+			t.logger.Error(ctx, err, "could not parse request")
+
+			return Status(err)
+		}
+
+		timestamps = append(timestamps, point.UTC().Format(constants.TimestampLayout))
+	}
+
+	schedule, err := t.useCase.GetSchedule(ctx, params)
+	if err != nil {
+		t.logger.Error(ctx, err, "could not get matching task schedule")
+		return Status(err)
+	}
+
+	matched := make(map[string]bool, len(schedule.List))
+	for _, timestamp := range schedule.List {
+		matched[timestamp] = true
+	}
+
+	suppressed := make(map[string]bool, len(schedule.Suppressed))
+	for _, timestamp := range schedule.Suppressed {
+		suppressed[timestamp] = true
+	}
+
+	for _, timestamp := range timestamps {
+		result := &ptaskpb.MatchResult{Timestamp: timestamp, Matched: matched[timestamp], Suppressed: suppressed[timestamp]}
+		if err = stream.Send(result); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *TaskService) params(ctx context.Context, req *ptaskpb.ListRequest) (*utils.ListQueryParams, error) {
+	params, err := utils.GetListQueryParams(ctx, t.logger, req.GetPeriod(), req.GetTz(), req.GetT1(), req.GetT2())
+	blackouts, errB := utils.GetBlackouts(ctx, t.logger, req.GetBlackouts())
+
+	// report every invalid field at once.
+	if err = httperrors.Collect(err, errB); err != nil {
+		return nil, err
+	}
+
+	params.Blackouts = blackouts
+	params.BlackoutSets = req.GetBlackoutSets()
+
+	return params, nil
+}
@@ -0,0 +1,342 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/KarolosLykos/ptask/internal/logger"
+	"github.com/KarolosLykos/ptask/internal/logger/log"
+	"github.com/KarolosLykos/ptask/internal/ptask/domain"
+	mock_ptask "github.com/KarolosLykos/ptask/internal/ptask/mock"
+	"github.com/KarolosLykos/ptask/internal/utils"
+	"github.com/KarolosLykos/ptask/internal/utils/httperrors"
+	"github.com/KarolosLykos/ptask/pkg/ptaskpb"
+)
+
+var validRequest = &ptaskpb.ListRequest{Period: "1d", Tz: "Europe/Athens", T1: "20210728T204603Z", T2: "20210731T123456Z"}
+
+func TestTaskService_List(t *testing.T) {
+	tt := []struct {
+		name        string
+		useCaseStub func(uc *mock_ptask.MockUseCase)
+		req         *ptaskpb.ListRequest
+		code        codes.Code
+		list        []string
+		fields      []string
+	}{
+		{
+			name:        "invalid period",
+			useCaseStub: func(uc *mock_ptask.MockUseCase) {},
+			req:         &ptaskpb.ListRequest{Period: "wrong", Tz: "Europe/Athens", T1: "20210728T204603Z", T2: "20210731T123456Z"},
+			code:        codes.InvalidArgument,
+		},
+		{
+			name:        "invalid blackout",
+			useCaseStub: func(uc *mock_ptask.MockUseCase) {},
+			req:         &ptaskpb.ListRequest{Period: "1d", Tz: "Europe/Athens", T1: "20210728T204603Z", T2: "20210731T123456Z", Blackouts: []string{"wrong"}},
+			code:        codes.InvalidArgument,
+		},
+		{
+			name:        "invalid period and blackouts",
+			useCaseStub: func(uc *mock_ptask.MockUseCase) {},
+			req:         &ptaskpb.ListRequest{Period: "wrong", Tz: "Europe/Athens", T1: "20210728T204603Z", T2: "20210731T123456Z", Blackouts: []string{"wrong", "20210729T000000Z"}},
+			code:        codes.InvalidArgument,
+			fields:      []string{"period", "blackout", "blackout"},
+		},
+		{
+			name: "unknown blackout set",
+			useCaseStub: func(uc *mock_ptask.MockUseCase) {
+				uc.EXPECT().GetList(gomock.Any(), gomock.Any()).Times(1).Return(nil, httperrors.ErrUnknownBlackout)
+			},
+			req:  &ptaskpb.ListRequest{Period: "1d", Tz: "Europe/Athens", T1: "20210728T204603Z", T2: "20210731T123456Z", BlackoutSets: []string{"holidays"}},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "use case error",
+			useCaseStub: func(uc *mock_ptask.MockUseCase) {
+				uc.EXPECT().GetList(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("boom"))
+			},
+			req:  validRequest,
+			code: codes.Internal,
+		},
+		{
+			name: "ok",
+			useCaseStub: func(uc *mock_ptask.MockUseCase) {
+				uc.EXPECT().GetList(gomock.Any(), gomock.Any()).Times(1).Return(domain.PtList{"20210728T210000Z", "20210729T210000Z"}, nil)
+			},
+			req:  validRequest,
+			code: codes.OK,
+			list: []string{"20210728T210000Z", "20210729T210000Z"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			useCase := mock_ptask.NewMockUseCase(ctrl)
+			tc.useCaseStub(useCase)
+
+			c := newClient(t, useCase)
+
+			res, err := c.List(context.Background(), tc.req)
+			require.Equal(t, tc.code, status.Code(err), err)
+
+			if tc.code == codes.OK {
+				assert.Equal(t, tc.list, res.GetTimestamps())
+			}
+
+			if tc.fields != nil {
+				require.Len(t, status.Convert(err).Details(), 1)
+
+				badRequest, ok := status.Convert(err).Details()[0].(*errdetails.BadRequest)
+				require.True(t, ok)
+
+				fields := make([]string, 0, len(badRequest.GetFieldViolations()))
+				for _, violation := range badRequest.GetFieldViolations() {
+					fields = append(fields, violation.GetField())
+				}
+
+				assert.Equal(t, tc.fields, fields)
+			}
+		})
+	}
+}
+
+func TestTaskService_Stream(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useCase := mock_ptask.NewMockUseCase(ctrl)
+	useCase.EXPECT().Each(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, _ *utils.ListQueryParams, fn func(string) bool) error {
+			for _, timestamp := range []string{"20210728T210000Z", "20210729T210000Z"} {
+				if !fn(timestamp) {
+					return nil
+				}
+			}
+
+			return nil
+		})
+
+	c := newClient(t, useCase)
+
+	stream, err := c.Stream(context.Background(), validRequest)
+	require.NoError(t, err)
+
+	var list []string
+
+	for {
+		occurrence, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		require.NoError(t, err)
+
+		list = append(list, occurrence.GetTimestamp())
+	}
+
+	assert.Equal(t, []string{"20210728T210000Z", "20210729T210000Z"}, list)
+
+	stream, err = c.Stream(context.Background(), &ptaskpb.ListRequest{Period: "1d", Tz: "Asia/Nowhere"})
+	require.NoError(t, err)
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, httperrors.ErrInvalidTimezone.Error(), status.Convert(err).Message())
+}
+
+// TestTaskService_StreamIncremental checks occurrences reach the client while the schedule is still computed.
+func TestTaskService_StreamIncremental(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	received := make(chan struct{})
+
+	useCase := mock_ptask.NewMockUseCase(ctrl)
+	useCase.EXPECT().Each(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(ctx context.Context, _ *utils.ListQueryParams, fn func(string) bool) error {
+			if !fn("20210728T210000Z") {
+				return nil
+			}
+
+			// the next occurrence is only computed once the client has the first one.
+			select {
+			case <-received:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+
+			fn("20210729T210000Z")
+
+			return errors.New("boom")
+		})
+
+	c := newClient(t, useCase)
+
+	stream, err := c.Stream(context.Background(), validRequest)
+	require.NoError(t, err)
+
+	occurrence, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, "20210728T210000Z", occurrence.GetTimestamp())
+
+	close(received)
+
+	occurrence, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, "20210729T210000Z", occurrence.GetTimestamp())
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestTaskService_Match(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useCase := mock_ptask.NewMockUseCase(ctrl)
+	useCase.EXPECT().GetSchedule(gomock.Any(), gomock.Any()).Times(1).
+		Return(&domain.PtSchedule{List: domain.PtList{"20210728T210000Z"}, Suppressed: domain.PtList{"20210729T210000Z"}}, nil)
+
+	c := newClient(t, useCase)
+
+	stream, err := c.Match(context.Background(), &ptaskpb.MatchRequest{
+		Task:       validRequest,
+		Timestamps: []string{"20210728T210000Z", "20210729T210000Z", "20210729T220000Z"},
+	})
+	require.NoError(t, err)
+
+	var results []*ptaskpb.MatchResult
+
+	for {
+		result, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		require.NoError(t, err)
+
+		results = append(results, result)
+	}
+
+	require.Len(t, results, 3)
+	assert.True(t, results[0].GetMatched())
+	assert.False(t, results[1].GetMatched())
+	assert.True(t, results[1].GetSuppressed())
+	assert.False(t, results[2].GetMatched())
+	assert.False(t, results[2].GetSuppressed())
+
+	stream, err = c.Match(context.Background(), &ptaskpb.MatchRequest{Task: validRequest, Timestamps: []string{"wrong"}})
+	require.NoError(t, err)
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, httperrors.ErrInvalidTimestamp.Error(), status.Convert(err).Message())
+}
+
+func TestStatus(t *testing.T) {
+	err := Status(httperrors.Collect(
+		httperrors.Invalid("period", "1d", httperrors.ErrInvalidPeriod),
+		httperrors.Invalid("tz", "Europe/Athens", httperrors.ErrInvalidTimezone),
+	))
+
+	s := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, s.Code())
+	assert.Equal(t, httperrors.ErrInvalidPeriod.Error(), s.Message())
+
+	require.Len(t, s.Details(), 1)
+
+	badRequest, ok := s.Details()[0].(*errdetails.BadRequest)
+	require.True(t, ok)
+
+	violations := badRequest.GetFieldViolations()
+	require.Len(t, violations, 2)
+	assert.Equal(t, "period", violations[0].GetField())
+	assert.Equal(t, httperrors.ErrInvalidPeriod.Error(), violations[0].GetDescription())
+	assert.Equal(t, "tz", violations[1].GetField())
+
+	// errors other than ValidationErrors carry no details.
+	assert.Empty(t, status.Convert(Status(httperrors.ErrUnknownBlackout)).Details())
+}
+
+func TestCode(t *testing.T) {
+	tt := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{name: "invalid period", err: httperrors.ErrInvalidPeriod, code: codes.InvalidArgument},
+		{name: "wrapped invalid timezone", err: fmt.Errorf("%w:%v", httperrors.ErrInvalidTimezone, "detail"), code: codes.InvalidArgument},
+		{name: "unknown blackout set", err: httperrors.ErrUnknownBlackout, code: codes.InvalidArgument},
+		{name: "unauthorized", err: httperrors.ErrUnauthorized, code: codes.Unauthenticated},
+		{name: "not found", err: httperrors.New("not_found", http.StatusNotFound, "not found"), code: codes.NotFound},
+		{name: "too large", err: httperrors.New("too_large", http.StatusRequestEntityTooLarge, "too large"), code: codes.ResourceExhausted},
+		{name: "not ready", err: httperrors.ErrNotReady, code: codes.Unavailable},
+		{name: "canceled", err: context.Canceled, code: codes.Canceled},
+		{name: "deadline", err: context.DeadlineExceeded, code: codes.DeadlineExceeded},
+		{name: "internal", err: httperrors.ErrInternalServer, code: codes.Internal},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.code, Code(tc.err))
+		})
+	}
+}
+
+func newClient(t *testing.T, useCase *mock_ptask.MockUseCase) ptaskpb.PeriodicTaskServiceClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+
+	server := grpc.NewServer()
+	ptaskpb.RegisterPeriodicTaskServiceServer(server, NewTaskService(getLogger(), useCase))
+
+	go func() { _ = server.Serve(lis) }()
+
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial(
+		"bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return ptaskpb.NewPeriodicTaskServiceClient(conn)
+}
+
+func getLogger() logger.Logger {
+	l := &logrus.Logger{
+		Out:          io.Discard,
+		Hooks:        make(logrus.LevelHooks),
+		ReportCaller: false,
+		ExitFunc:     os.Exit,
+		Level:        logrus.DebugLevel,
+		Formatter:    &logrus.JSONFormatter{},
+	}
+
+	return log.New(l)
+}
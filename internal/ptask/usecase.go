@@ -2,6 +2,7 @@ package ptask
 
 import (
 	"context"
+	"time"
 
 	"github.com/KarolosLykos/ptask/internal/ptask/domain"
 	"github.com/KarolosLykos/ptask/internal/utils"
@@ -9,4 +10,13 @@ import (
 
 type UseCase interface {
 	GetList(ctx context.Context, params *utils.ListQueryParams) (domain.PtList, error)
+	GetSchedule(ctx context.Context, params *utils.ListQueryParams) (*domain.PtSchedule, error)
+	Each(ctx context.Context, params *utils.ListQueryParams, fn func(timestamp string) bool) error
+	Display(ctx context.Context, list domain.PtList, zones []*time.Location) ([]domain.PtOccurrence, error)
+	Compose(ctx context.Context, params *utils.ComposeParams) (domain.PtList, error)
+	Describe(ctx context.Context, params *utils.DescribeParams) (*domain.PtDescription, error)
+	Parse(ctx context.Context, params *utils.ParseParams) (*domain.PtParse, error)
+	Timezones(ctx context.Context, search string) ([]domain.PtTimezone, error)
+	Transitions(ctx context.Context, params *utils.TransitionParams) ([]domain.PtTransition, error)
+	Collisions(ctx context.Context, params *utils.CollisionParams) (*domain.CollisionReport, error)
 }
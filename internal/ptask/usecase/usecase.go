@@ -2,50 +2,406 @@ package usecase
 
 import (
 	"context"
+	"strings"
+	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+
 	"github.com/KarolosLykos/ptask/internal/constants"
 	"github.com/KarolosLykos/ptask/internal/logger"
 	"github.com/KarolosLykos/ptask/internal/ptask"
 	"github.com/KarolosLykos/ptask/internal/ptask/domain"
+	"github.com/KarolosLykos/ptask/internal/tracing"
+	"github.com/KarolosLykos/ptask/internal/tzdata"
 	"github.com/KarolosLykos/ptask/internal/utils"
 	"github.com/KarolosLykos/ptask/internal/utils/httperrors"
 )
 
 type periodicTaskUC struct {
-	logger logger.Logger
+	logger    logger.Logger
+	blackouts *Blackouts
+}
+
+// Option configures the periodic task useCase.
+type Option func(*periodicTaskUC)
+
+// WithBlackoutSets registers named blackout sets that requests can refer to.
+func WithBlackoutSets(sets domain.BlackoutSets) Option {
+	return WithBlackouts(NewBlackouts(sets))
+}
+
+// WithBlackouts makes the useCase read the named blackout sets from b, so they can be replaced while it
+// serves requests.
+func WithBlackouts(b *Blackouts) Option {
+	return func(p *periodicTaskUC) {
+		p.blackouts = b
+	}
 }
 
-func NewPeriodicTaskUC(logger logger.Logger) ptask.UseCase {
-	return &periodicTaskUC{logger: logger}
+// Blackouts holds named blackout sets, replaced atomically.
+type Blackouts struct {
+	sets atomic.Pointer[domain.BlackoutSets]
+}
+
+func NewBlackouts(sets domain.BlackoutSets) *Blackouts {
+	b := &Blackouts{}
+	b.Store(sets)
+
+	return b
 }
 
-func (p *periodicTaskUC) GetList(ctx context.Context, params *utils.ListQueryParams) (domain.PtList, error) {
+// Load returns the current sets.
+func (b *Blackouts) Load() domain.BlackoutSets {
+	return *b.sets.Load()
+}
+
+// Store replaces the sets; requests already being served keep the ones they started with.
+func (b *Blackouts) Store(sets domain.BlackoutSets) {
+	if sets == nil {
+		sets = domain.BlackoutSets{}
+	}
+
+	b.sets.Store(&sets)
+}
+
+func NewPeriodicTaskUC(logger logger.Logger, opts ...Option) ptask.UseCase {
+	p := &periodicTaskUC{logger: logger, blackouts: NewBlackouts(nil)}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+func (p *periodicTaskUC) GetList(ctx context.Context, params *utils.ListQueryParams) (list domain.PtList, err error) {
 	p.logger.Trace(ctx, "periodicTaskU.GetList")
 	defer p.logger.Trace(ctx, "periodicTaskU.GetList")
 
+	ctx, span := tracing.Start(ctx, "periodicTaskU.GetList", listAttributes(params)...)
+	defer func() {
+		span.SetAttributes(tracing.ResultCountKey.Int(len(list)))
+		tracing.End(span, err)
+	}()
+
+	schedule, err := p.GetSchedule(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return schedule.List, nil
+}
+
+func (p *periodicTaskUC) GetSchedule(ctx context.Context, params *utils.ListQueryParams) (res *domain.PtSchedule, err error) {
+	p.logger.Trace(ctx, "periodicTaskU.GetSchedule")
+	defer p.logger.Trace(ctx, "periodicTaskU.GetSchedule")
+
+	ctx, span := tracing.Start(ctx, "periodicTaskU.GetSchedule", listAttributes(params)...)
+	defer func() {
+		if res != nil {
+			span.SetAttributes(tracing.ResultCountKey.Int(len(res.List)), tracing.SuppressedCountKey.Int(len(res.Suppressed)))
+		}
+
+		tracing.End(span, err)
+	}()
+
+	schedule := &domain.PtSchedule{List: domain.PtList{}, Suppressed: domain.PtList{}}
+
+	err = p.each(ctx, params, func(timestamp string, suppressed bool) bool {
+		if suppressed {
+			schedule.Suppressed = append(schedule.Suppressed, timestamp)
+			return true
+		}
+
+		schedule.List = append(schedule.List, timestamp)
+
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+// Each calls fn with the listed timestamps of the schedule, in order, as they are computed, so callers can
+// hand them out without holding the whole list. It stops when fn returns false.
+func (p *periodicTaskUC) Each(ctx context.Context, params *utils.ListQueryParams, fn func(timestamp string) bool) (err error) {
+	p.logger.Trace(ctx, "periodicTaskU.Each")
+	defer p.logger.Trace(ctx, "periodicTaskU.Each")
+
+	listed := 0
+
+	ctx, span := tracing.Start(ctx, "periodicTaskU.Each", listAttributes(params)...)
+	defer func() {
+		span.SetAttributes(tracing.ResultCountKey.Int(listed))
+		tracing.End(span, err)
+	}()
+
+	return p.each(ctx, params, func(timestamp string, suppressed bool) bool {
+		if suppressed {
+			return true
+		}
+
+		listed++
+
+		return fn(timestamp)
+	})
+}
+
+// each calls fn with every occurrence of the schedule and whether a blackout suppresses it, stopping when fn
+// returns false or after params.Limit listed occurrences.
+func (p *periodicTaskUC) each(ctx context.Context, params *utils.ListQueryParams, fn func(timestamp string, suppressed bool) bool) error {
+	blackouts, err := p.getBlackouts(params)
+	if err != nil {
+		return err
+	}
+
 	task, err := domain.NewPeriodicTask(ctx, p.logger, params.Period, params.Timezone, params.T1)
+	if err != nil {
+		return err
+	}
+
+	listed := 0
+
+	err = task.Each(params.T1, params.T2, func(point time.Time) bool {
+		timestamp := point.UTC().Format(constants.TimestampLayout)
+		if domain.InBlackout(point, blackouts) {
+			return fn(timestamp, true)
+		}
+
+		listed++
+
+		return fn(timestamp, false) && (params.Limit == 0 || listed < params.Limit)
+	})
+	if err != nil {
+		return httperrors.WrapPeriod(err)
+	}
+
+	return nil
+}
+
+// listAttributes describes the task and the window of a list request on its spans.
+func listAttributes(params *utils.ListQueryParams) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		tracing.PeriodKey.String(params.Period.String()),
+		tracing.TimezoneKey.String(params.Timezone.String()),
+		tracing.WindowKey.Float64(params.T2.Sub(params.T1).Seconds()),
+	}
+}
+
+func (p *periodicTaskUC) Display(
+	ctx context.Context,
+	list domain.PtList,
+	zones []*time.Location,
+) ([]domain.PtOccurrence, error) {
+	p.logger.Trace(ctx, "periodicTaskU.Display")
+	defer p.logger.Trace(ctx, "periodicTaskU.Display")
+
+	return domain.Display(list, zones)
+}
+
+func (p *periodicTaskUC) Compose(ctx context.Context, params *utils.ComposeParams) (domain.PtList, error) {
+	p.logger.Trace(ctx, "periodicTaskU.Compose")
+	defer p.logger.Trace(ctx, "periodicTaskU.Compose")
+
+	it, err := p.buildIterator(ctx, params.Expr, params)
 	if err != nil {
 		return nil, err
 	}
 
 	list := domain.PtList{}
-	for point := task.InvocationPoint; point.Before(params.T2); {
+
+	for {
+		point, ok, err := it.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			return list, nil
+		}
+
 		list = append(list, point.UTC().Format(constants.TimestampLayout))
+	}
+}
+
+func (p *periodicTaskUC) Describe(ctx context.Context, params *utils.DescribeParams) (*domain.PtDescription, error) {
+	p.logger.Trace(ctx, "periodicTaskU.Describe")
+	defer p.logger.Trace(ctx, "periodicTaskU.Describe")
+
+	description, err := domain.Describe(params.Period, params.Timezone, params.Lang)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PtDescription{
+		Period:      params.Period.String(),
+		Timezone:    params.Timezone.String(),
+		Lang:        params.Lang,
+		Description: description,
+	}, nil
+}
+
+func (p *periodicTaskUC) Parse(ctx context.Context, params *utils.ParseParams) (*domain.PtParse, error) {
+	p.logger.Trace(ctx, "periodicTaskU.Parse")
+	defer p.logger.Trace(ctx, "periodicTaskU.Parse")
+
+	parsed, err := domain.ParseSchedule(params.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	if rule := parsed.Rule; rule != nil {
+		description, err := rule.Describe(params.Timezone, params.Lang)
+		if err != nil {
+			return nil, err
+		}
 
-		switch task.Period.PeriodType {
-		case constants.Year:
-			point = point.AddDate(task.Period.Value, 0, 0)
-		case constants.Month:
-			point = point.AddDate(0, task.Period.Value, 0)
-		case constants.Day:
-			point = point.AddDate(0, 0, task.Period.Value)
-		case constants.Hour:
-			point = point.Add(time.Duration(task.Period.Value) * time.Hour)
-		default:
-			return nil, httperrors.ErrInvalidPeriod
+		next := make(domain.PtList, 0, params.Count)
+		for _, point := range rule.Next(params.T1, params.Timezone, params.Count) {
+			next = append(next, point.UTC().Format(constants.TimestampLayout))
 		}
+
+		return &domain.PtParse{
+			Query:       params.Query,
+			Rule:        rule.String(),
+			Description: description,
+			Next:        next,
+		}, nil
+	}
+
+	period := parsed.Period
+
+	description, err := domain.Describe(period, params.Timezone, params.Lang)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := domain.NewPeriodicTask(ctx, p.logger, period, params.Timezone, params.T1)
+	if err != nil {
+		return nil, err
+	}
+
+	next := make(domain.PtList, 0, params.Count)
+	for point := task.InvocationPoint; len(next) < params.Count; {
+		next = append(next, point.UTC().Format(constants.TimestampLayout))
+
+		if point, err = task.Next(point); err != nil {
+			return nil, err
+		}
+	}
+
+	return &domain.PtParse{
+		Query:       params.Query,
+		Period:      period.String(),
+		Description: description,
+		Next:        next,
+	}, nil
+}
+
+func (p *periodicTaskUC) Timezones(ctx context.Context, search string) ([]domain.PtTimezone, error) {
+	p.logger.Trace(ctx, "periodicTaskU.Timezones")
+	defer p.logger.Trace(ctx, "periodicTaskU.Timezones")
+
+	names := tzdata.Names()
+	now := time.Now()
+	search = strings.ToLower(search)
+	timezones := []domain.PtTimezone{}
+
+	for _, name := range names {
+		if !strings.Contains(strings.ToLower(name), search) {
+			continue
+		}
+
+		loc, err := tzdata.LoadLocation(name)
+		if err != nil {
+			return nil, err
+		}
+
+		timezones = append(timezones, domain.NewPtTimezone(loc, now))
+	}
+
+	return timezones, nil
+}
+
+func (p *periodicTaskUC) Transitions(ctx context.Context, params *utils.TransitionParams) ([]domain.PtTransition, error) {
+	p.logger.Trace(ctx, "periodicTaskU.Transitions")
+	defer p.logger.Trace(ctx, "periodicTaskU.Transitions")
+
+	return domain.Transitions(params.Timezone, params.T1, params.T2), nil
+}
+
+func (p *periodicTaskUC) Collisions(ctx context.Context, params *utils.CollisionParams) (*domain.CollisionReport, error) {
+	p.logger.Trace(ctx, "periodicTaskU.Collisions")
+	defer p.logger.Trace(ctx, "periodicTaskU.Collisions")
+
+	schedules := make([]domain.NamedIterator, 0, len(params.Schedules))
+
+	for _, s := range params.Schedules {
+		task, err := domain.NewPeriodicTask(ctx, p.logger, s.Period, params.Timezone, params.T1)
+		if err != nil {
+			return nil, err
+		}
+
+		schedules = append(schedules, domain.NamedIterator{Name: s.Name, It: domain.NewTaskIterator(task, params.T2)})
+	}
+
+	return domain.AnalyzeCollisions(schedules, params.Threshold)
+}
+
+// buildIterator turns an expression tree into a tree of lazy iterators over the [t1, t2) window.
+func (p *periodicTaskUC) buildIterator(
+	ctx context.Context,
+	expr *domain.Expression,
+	params *utils.ComposeParams,
+) (domain.Iterator, error) {
+	if expr.Period != nil {
+		task, err := domain.NewPeriodicTask(ctx, p.logger, expr.Period, params.Timezone, params.T1)
+		if err != nil {
+			return nil, err
+		}
+
+		return domain.NewTaskIterator(task, params.T2), nil
+	}
+
+	args := make([]domain.Iterator, 0, len(expr.Args))
+
+	for _, arg := range expr.Args {
+		it, err := p.buildIterator(ctx, arg, params)
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, it)
+	}
+
+	switch expr.Op {
+	case constants.Union:
+		return domain.Union(args...), nil
+	case constants.Intersect:
+		return domain.Intersect(args...), nil
+	case constants.Except:
+		return domain.Except(args[0], args[1:]...), nil
+	default:
+		return nil, httperrors.Wrapf(httperrors.ErrInvalidExpression, "unknown operator %q", expr.Op)
+	}
+}
+
+// getBlackouts merges the inline blackouts with the ones of the referenced named sets.
+func (p *periodicTaskUC) getBlackouts(params *utils.ListQueryParams) ([]domain.Blackout, error) {
+	blackouts := append([]domain.Blackout{}, params.Blackouts...)
+	sets := p.blackouts.Load()
+
+	for _, name := range params.BlackoutSets {
+		set, ok := sets[name]
+		if !ok {
+			return nil, httperrors.Wrapf(httperrors.ErrUnknownBlackout, "%s", name)
+		}
+
+		blackouts = append(blackouts, set...)
 	}
 
-	return list, nil
+	return blackouts, nil
 }
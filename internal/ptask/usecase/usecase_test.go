@@ -83,6 +83,315 @@ func TestPeriodicTaskUC_GetList(t *testing.T) {
 	}
 }
 
+func TestPeriodicTaskUC_GetSchedule(t *testing.T) {
+	l := getLogger()
+	ctx := context.TODO()
+
+	freeze := []domain.Blackout{
+		{Start: time.Date(2021, 7, 30, 0, 0, 0, 0, time.UTC), End: time.Date(2021, 8, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	useCase := NewPeriodicTaskUC(l, WithBlackoutSets(domain.BlackoutSets{"freeze": freeze}))
+
+	tt := []struct {
+		name         string
+		blackouts    []domain.Blackout
+		blackoutSets []string
+		limit        int
+		list         []string
+		suppressed   []string
+		err          error
+	}{
+		{
+			name:       "no blackouts",
+			list:       []string{"20210728T210000Z", "20210729T210000Z", "20210730T210000Z", "20210731T210000Z", "20210801T210000Z"},
+			suppressed: []string{},
+		},
+		{
+			name: "inline blackout",
+			blackouts: []domain.Blackout{
+				{Start: time.Date(2021, 7, 29, 21, 0, 0, 0, time.UTC), End: time.Date(2021, 7, 30, 21, 0, 0, 0, time.UTC)},
+			},
+			list:       []string{"20210728T210000Z", "20210730T210000Z", "20210731T210000Z", "20210801T210000Z"},
+			suppressed: []string{"20210729T210000Z"},
+		},
+		{
+			name:         "named blackout set",
+			blackoutSets: []string{"freeze"},
+			list:         []string{"20210728T210000Z", "20210729T210000Z", "20210801T210000Z"},
+			suppressed:   []string{"20210730T210000Z", "20210731T210000Z"},
+		},
+		{
+			name:         "limit",
+			blackoutSets: []string{"freeze"},
+			limit:        3,
+			list:         []string{"20210728T210000Z", "20210729T210000Z", "20210801T210000Z"},
+			suppressed:   []string{"20210730T210000Z", "20210731T210000Z"},
+		},
+		{
+			name:       "limit within the window",
+			limit:      2,
+			list:       []string{"20210728T210000Z", "20210729T210000Z"},
+			suppressed: []string{},
+		},
+		{
+			name:         "unknown blackout set",
+			blackoutSets: []string{"unknown"},
+			err:          httperrors.ErrUnknownBlackout,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			params := getParams(t, constants.Day, "20210728T204603Z", "20210802T123456Z")
+			params.Blackouts = tc.blackouts
+			params.BlackoutSets = tc.blackoutSets
+			params.Limit = tc.limit
+
+			schedule, err := useCase.GetSchedule(ctx, params)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+				assert.ElementsMatch(t, tc.list, schedule.List)
+				assert.ElementsMatch(t, tc.suppressed, schedule.Suppressed)
+			}
+		})
+	}
+}
+
+func TestPeriodicTaskUC_Each(t *testing.T) {
+	l := getLogger()
+	ctx := context.TODO()
+
+	freeze := []domain.Blackout{
+		{Start: time.Date(2021, 7, 30, 0, 0, 0, 0, time.UTC), End: time.Date(2021, 8, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	useCase := NewPeriodicTaskUC(l, WithBlackoutSets(domain.BlackoutSets{"freeze": freeze}))
+
+	params := getParams(t, constants.Day, "20210728T204603Z", "20210802T123456Z")
+	params.BlackoutSets = []string{"freeze"}
+
+	list := []string{}
+	err := useCase.Each(ctx, params, func(timestamp string) bool {
+		list = append(list, timestamp)
+		return true
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"20210728T210000Z", "20210729T210000Z", "20210801T210000Z"}, list)
+
+	// returning false stops the schedule.
+	list = []string{}
+	err = useCase.Each(ctx, params, func(timestamp string) bool {
+		list = append(list, timestamp)
+		return len(list) < 2
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"20210728T210000Z", "20210729T210000Z"}, list)
+
+	params.BlackoutSets = []string{"unknown"}
+	err = useCase.Each(ctx, params, func(string) bool { return true })
+	assert.ErrorIs(t, err, httperrors.ErrUnknownBlackout)
+}
+
+func TestPeriodicTaskUC_Display(t *testing.T) {
+	l := getLogger()
+	ctx := context.TODO()
+
+	useCase := NewPeriodicTaskUC(l)
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	occurrences, err := useCase.Display(ctx, domain.PtList{"20210728T210000Z"}, []*time.Location{tokyo})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.PtOccurrence{{Timestamp: "20210728T210000Z", Local: map[string]string{"Asia/Tokyo": "2021-07-29T06:00:00+09:00"}}}, occurrences)
+}
+
+func TestPeriodicTaskUC_Compose(t *testing.T) {
+	l := getLogger()
+	ctx := context.TODO()
+
+	useCase := NewPeriodicTaskUC(l)
+
+	hourly := &domain.Expression{Period: &domain.Period{Value: 1, PeriodType: constants.Hour}}
+	sixHourly := &domain.Expression{Period: &domain.Period{Value: 6, PeriodType: constants.Hour}}
+	daily := &domain.Expression{Period: &domain.Period{Value: 1, PeriodType: constants.Day}}
+
+	tt := []struct {
+		name string
+		expr *domain.Expression
+		list []string
+		err  error
+	}{
+		{
+			name: "intersect",
+			expr: &domain.Expression{Op: constants.Intersect, Args: []*domain.Expression{hourly, sixHourly}},
+			list: []string{"20210714T210000Z", "20210715T030000Z", "20210715T090000Z"},
+		},
+		{
+			name: "union",
+			expr: &domain.Expression{Op: constants.Union, Args: []*domain.Expression{sixHourly, daily}},
+			list: []string{"20210714T210000Z", "20210715T030000Z", "20210715T090000Z"},
+		},
+		{
+			name: "except",
+			expr: &domain.Expression{Op: constants.Except, Args: []*domain.Expression{sixHourly, daily}},
+			list: []string{"20210715T030000Z", "20210715T090000Z"},
+		},
+		{
+			name: "invalid period",
+			expr: &domain.Expression{Period: &domain.Period{Value: 1, PeriodType: "wrong"}},
+			err:  httperrors.ErrInvalidPeriod,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			p := getParams(t, constants.Hour, "20210714T204603Z", "20210715T123456Z")
+			params := &utils.ComposeParams{Expr: tc.expr, Timezone: p.Timezone, T1: p.T1, T2: p.T2}
+
+			list, err := useCase.Compose(ctx, params)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, domain.PtList(tc.list), list)
+			}
+		})
+	}
+}
+
+func TestPeriodicTaskUC_Describe(t *testing.T) {
+	l := getLogger()
+	ctx := context.TODO()
+
+	useCase := NewPeriodicTaskUC(l)
+
+	p := getParams(t, constants.Month, "20210714T204603Z", "20210715T123456Z")
+
+	description, err := useCase.Describe(ctx, &utils.DescribeParams{Period: p.Period, Timezone: p.Timezone, Lang: constants.LangEnglish})
+	require.NoError(t, err)
+	assert.Equal(t, &domain.PtDescription{
+		Period:      "1mo",
+		Timezone:    "Europe/Athens",
+		Lang:        constants.LangEnglish,
+		Description: "Every month on the 1st at 00:00 (Europe/Athens)",
+	}, description)
+
+	_, err = useCase.Describe(ctx, &utils.DescribeParams{Period: p.Period, Timezone: p.Timezone, Lang: "fr"})
+	assert.ErrorIs(t, err, httperrors.ErrUnsupportedLanguage)
+}
+
+func TestPeriodicTaskUC_Parse(t *testing.T) {
+	l := getLogger()
+	ctx := context.TODO()
+
+	useCase := NewPeriodicTaskUC(l)
+
+	p := getParams(t, constants.Hour, "20210714T204603Z", "20210715T123456Z")
+
+	parsed, err := useCase.Parse(ctx, &utils.ParseParams{Query: "every 6 hours", Timezone: p.Timezone, T1: p.T1, Count: 3, Lang: constants.LangEnglish})
+	require.NoError(t, err)
+	assert.Equal(t, &domain.PtParse{
+		Query:       "every 6 hours",
+		Period:      "6h",
+		Description: "Every 6 hours on the hour (Europe/Athens)",
+		Next:        domain.PtList{"20210714T210000Z", "20210715T030000Z", "20210715T090000Z"},
+	}, parsed)
+
+	// 20210714T204603Z is a Wednesday, the week of the rule starts on Monday the 12th.
+	parsed, err = useCase.Parse(ctx, &utils.ParseParams{Query: "every other Monday at 9am", Timezone: p.Timezone, T1: p.T1, Count: 3, Lang: constants.LangEnglish})
+	require.NoError(t, err)
+	assert.Equal(t, &domain.PtParse{
+		Query:       "every other Monday at 9am",
+		Rule:        "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO;BYHOUR=9;BYMINUTE=0",
+		Description: "Every 2 weeks on Monday at 09:00 (Europe/Athens)",
+		Next:        domain.PtList{"20210726T060000Z", "20210809T060000Z", "20210823T060000Z"},
+	}, parsed)
+
+	parsed, err = useCase.Parse(ctx, &utils.ParseParams{Query: "every 6 hours", Timezone: p.Timezone, T1: p.T1, Count: 1, Lang: constants.LangGerman})
+	require.NoError(t, err)
+	assert.Equal(t, "Alle 6 Stunden zur vollen Stunde (Europe/Athens)", parsed.Description)
+
+	parsed, err = useCase.Parse(ctx, &utils.ParseParams{Query: "every other Monday at 9am", Timezone: p.Timezone, T1: p.T1, Count: 1, Lang: constants.LangGreek})
+	require.NoError(t, err)
+	assert.Equal(t, "Κάθε 2 εβδομάδες, Δευτέρα, στις 09:00 (Europe/Athens)", parsed.Description)
+
+	for _, query := range []string{"every 6 hours", "every other Monday at 9am"} {
+		_, err = useCase.Parse(ctx, &utils.ParseParams{Query: query, Timezone: p.Timezone, T1: p.T1, Count: 1, Lang: "fr"})
+		assert.ErrorIs(t, err, httperrors.ErrUnsupportedLanguage)
+	}
+
+	_, err = useCase.Parse(ctx, &utils.ParseParams{Query: "every weekday at 25:00", Timezone: p.Timezone, T1: p.T1, Count: 3, Lang: constants.LangEnglish})
+	assert.ErrorIs(t, err, httperrors.ErrUnparsableSchedule)
+}
+
+func TestPeriodicTaskUC_Timezones(t *testing.T) {
+	l := getLogger()
+	ctx := context.TODO()
+
+	useCase := NewPeriodicTaskUC(l)
+
+	timezones, err := useCase.Timezones(ctx, "athens")
+	require.NoError(t, err)
+	require.Len(t, timezones, 1)
+	assert.Equal(t, "Europe/Athens", timezones[0].Name)
+
+	timezones, err = useCase.Timezones(ctx, "no such zone")
+	require.NoError(t, err)
+	assert.Empty(t, timezones)
+}
+
+func TestPeriodicTaskUC_Transitions(t *testing.T) {
+	l := getLogger()
+	ctx := context.TODO()
+
+	useCase := NewPeriodicTaskUC(l)
+
+	p := getParams(t, constants.Hour, "20210101T000000Z", "20220101T000000Z")
+
+	transitions, err := useCase.Transitions(ctx, &utils.TransitionParams{Timezone: p.Timezone, T1: p.T1, T2: p.T2})
+	require.NoError(t, err)
+	require.Len(t, transitions, 2)
+	assert.Equal(t, "20210328T010000Z", transitions[0].At)
+	assert.Equal(t, "20211031T010000Z", transitions[1].At)
+}
+
+func TestPeriodicTaskUC_Collisions(t *testing.T) {
+	l := getLogger()
+	ctx := context.TODO()
+
+	useCase := NewPeriodicTaskUC(l)
+
+	p := getParams(t, constants.Hour, "20210714T204603Z", "20210715T123456Z")
+	params := &utils.CollisionParams{
+		Schedules: []utils.NamedPeriod{
+			{Name: "sync", Period: &domain.Period{Value: 1, PeriodType: constants.Hour}},
+			{Name: "backup", Period: &domain.Period{Value: 6, PeriodType: constants.Hour}},
+		},
+		Threshold: 1,
+		Timezone:  p.Timezone,
+		T1:        p.T1,
+		T2:        p.T2,
+	}
+
+	report, err := useCase.Collisions(ctx, params)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, report.Peak)
+	assert.Equal(t, domain.PtList{"20210714T210000Z", "20210715T030000Z", "20210715T090000Z"}, report.PeakPoints)
+	assert.Equal(t, map[int]int{1: 13, 2: 3}, report.Histogram)
+	assert.Len(t, report.Collisions, 3)
+	assert.Equal(t, map[string]string{"backup": "0s", "sync": "30m0s"}, report.Offsets)
+
+	params.Schedules = append(params.Schedules, utils.NamedPeriod{Name: "wrong", Period: &domain.Period{Value: 1, PeriodType: "wrong"}})
+
+	_, err = useCase.Collisions(ctx, params)
+	assert.ErrorIs(t, err, httperrors.ErrInvalidPeriod)
+}
+
 func getLogger() logger.Logger {
 	l := &logrus.Logger{
 		Out:          io.Discard,
@@ -0,0 +1,181 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/KarolosLykos/ptask/internal/constants"
+	"github.com/KarolosLykos/ptask/internal/correlation"
+	"github.com/KarolosLykos/ptask/internal/logger"
+	"github.com/KarolosLykos/ptask/internal/tracing"
+	"github.com/KarolosLykos/ptask/internal/utils/httperrors"
+)
+
+type interceptors struct {
+	logger logger.Logger
+}
+
+func newInterceptors(logger logger.Logger) *interceptors {
+	return &interceptors{logger: logger}
+}
+
+// correlateUnary identifies a unary RPC with the x-request-id and traceparent metadata it was sent with, or
+// generated ones, logs them with every entry of the RPC and echoes them in the header.
+func (i *interceptors) correlateUnary(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	ctx, span, ids := correlate(ctx, info.FullMethod)
+	defer span.End()
+
+	_ = grpc.SetHeader(ctx, header(ids))
+
+	return handler(correlation.WithIDs(ctx, ids), req)
+}
+
+// correlateStream identifies a streaming RPC like correlateUnary does.
+func (i *interceptors) correlateStream(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	ctx, span, ids := correlate(ss.Context(), info.FullMethod)
+	defer span.End()
+
+	_ = ss.SetHeader(header(ids))
+
+	return handler(srv, &serverStream{ServerStream: ss, ctx: correlation.WithIDs(ctx, ids)})
+}
+
+// serverStream is a grpc.ServerStream with the context of the RPC replaced.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// correlate starts the span of an RPC, child of the one of its traceparent metadata, and returns its IDs.
+func correlate(ctx context.Context, method string) (context.Context, trace.Span, correlation.IDs) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	var requestID string
+	if v := md.Get(constants.RequestIDHeader); len(v) > 0 {
+		requestID = v[0]
+	}
+
+	ctx, span := tracing.StartServer(ctx, metadataCarrier(md), method, attribute.String("rpc.method", method))
+
+	return ctx, span, correlation.New(requestID, span.SpanContext())
+}
+
+// metadataCarrier reads the trace context of an RPC from its metadata, whose keys are lowercase.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if v := metadata.MD(c).Get(key); len(v) > 0 {
+		return v[0]
+	}
+
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
+
+func header(ids correlation.IDs) metadata.MD {
+	return metadata.Pairs(constants.RequestIDHeader, ids.RequestID, constants.TraceParentHeader, ids.TraceParent)
+}
+
+// recoverPanicUnary handles any panic that may occur in a unary RPC.
+func (i *interceptors) recoverPanicUnary(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (resp interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = i.recovered(ctx, r)
+		}
+	}()
+
+	return handler(ctx, req)
+}
+
+// recoverPanicStream handles any panic that may occur in a streaming RPC.
+func (i *interceptors) recoverPanicStream(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = i.recovered(ss.Context(), r)
+		}
+	}()
+
+	return handler(srv, ss)
+}
+
+func (i *interceptors) recovered(ctx context.Context, r interface{}) error {
+	i.logger.Error(ctx, httperrors.Wrapf(httperrors.ErrRecoverPanic, "%v", r), "interceptor recovering from panic error")
+
+	return status.Error(codes.Internal, httperrors.ErrRecoverPanic.Error())
+}
+
+// logInfoUnary logs unary RPC information.
+func (i *interceptors) logInfoUnary(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	start := time.Now()
+
+	resp, err := handler(ctx, req)
+
+	i.logger.Info(ctx, fmt.Sprintf("%s %s took %s", info.FullMethod, status.Code(err), time.Since(start).String()))
+
+	return resp, err
+}
+
+// logInfoStream logs streaming RPC information.
+func (i *interceptors) logInfoStream(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	start := time.Now()
+
+	err := handler(srv, ss)
+
+	i.logger.Info(ss.Context(), fmt.Sprintf("%s %s took %s", info.FullMethod, status.Code(err), time.Since(start).String()))
+
+	return err
+}
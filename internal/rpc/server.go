@@ -0,0 +1,90 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"google.golang.org/grpc"
+
+	"github.com/KarolosLykos/ptask/internal/logger"
+	"github.com/KarolosLykos/ptask/internal/ptask"
+	taskRpc "github.com/KarolosLykos/ptask/internal/ptask/rpc"
+	"github.com/KarolosLykos/ptask/pkg/ptaskpb"
+)
+
+type Server struct {
+	logger logger.Logger
+	addr   string
+	server *grpc.Server
+	errs   chan error
+}
+
+func New(logger logger.Logger, addr string, useCase ptask.UseCase) *Server {
+	// setting up interceptors.
+	i := newInterceptors(logger)
+
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(i.correlateUnary, i.recoverPanicUnary, i.logInfoUnary),
+		grpc.ChainStreamInterceptor(i.correlateStream, i.recoverPanicStream, i.logInfoStream),
+	)
+
+	// register task service.
+	ptaskpb.RegisterPeriodicTaskServiceServer(server, taskRpc.NewTaskService(logger, useCase))
+
+	return &Server{logger: logger, addr: addr, server: server, errs: make(chan error, 1)}
+}
+
+// Serve serves gRPC on an existing listener, e.g. a bufconn one in tests.
+func (s *Server) Serve(lis net.Listener) error {
+	return s.server.Serve(lis)
+}
+
+// Start listens on the address of the server and serves gRPC in the background. It fails when the address
+// cannot be listened on; should serving fail later, the error is sent on Err.
+func (s *Server) Start(ctx context.Context) error {
+	s.logger.Info(ctx, "starting grpc server...")
+
+	lis, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("could not listen on %s: %w", s.addr, err)
+	}
+
+	go func() {
+		if err := s.server.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			s.errs <- fmt.Errorf("grpc server on %s failed: %w", s.addr, err)
+		}
+	}()
+
+	s.logger.Info(ctx, "grpc server started on: ", lis.Addr().String())
+
+	return nil
+}
+
+// Err receives the error the server fails with after it started.
+func (s *Server) Err() <-chan error {
+	return s.errs
+}
+
+// Shutdown stops accepting RPCs and waits for the pending ones, streams included, unless ctx is done first,
+// in which case they are cancelled and the error of ctx is returned.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.logger.Debug(ctx, "shutting down grpc server...")
+
+	stopped := make(chan struct{})
+
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,152 @@
+package rpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/KarolosLykos/ptask/internal/correlation"
+	"github.com/KarolosLykos/ptask/internal/logger"
+	"github.com/KarolosLykos/ptask/internal/logger/log"
+	"github.com/KarolosLykos/ptask/internal/ptask"
+	"github.com/KarolosLykos/ptask/internal/ptask/domain"
+	mock_ptask "github.com/KarolosLykos/ptask/internal/ptask/mock"
+	"github.com/KarolosLykos/ptask/internal/ptask/usecase"
+	"github.com/KarolosLykos/ptask/internal/utils/httperrors"
+	"github.com/KarolosLykos/ptask/pkg/ptaskpb"
+)
+
+var request = &ptaskpb.ListRequest{Period: "1d", Tz: "Europe/Athens", T1: "20210728T204603Z", T2: "20210731T123456Z"}
+
+func TestServer_List(t *testing.T) {
+	c := newClient(t, usecase.NewPeriodicTaskUC(getLogger()))
+
+	res, err := c.List(context.Background(), request)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"20210728T210000Z", "20210729T210000Z", "20210730T210000Z"}, res.GetTimestamps())
+}
+
+func TestServer_Correlate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useCase := mock_ptask.NewMockUseCase(ctrl)
+	useCase.EXPECT().GetList(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(ctx context.Context, _ interface{}) (interface{}, error) {
+		ids, ok := correlation.FromContext(ctx)
+		require.True(t, ok)
+		assert.Equal(t, "req-1", ids.RequestID)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", ids.TraceID)
+
+		return domain.PtList{}, nil
+	})
+
+	c := newClient(t, useCase)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(),
+		"x-request-id", "req-1", "traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	var header metadata.MD
+
+	_, err := c.List(ctx, request, grpc.Header(&header))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"req-1"}, header.Get("x-request-id"))
+	require.Len(t, header.Get("traceparent"), 1)
+	assert.Regexp(t, `^00-4bf92f3577b34da6a3ce929d0e0e4736-[0-9a-f]{16}-01$`, header.Get("traceparent")[0])
+}
+
+func TestServer_RecoverPanic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	useCase := mock_ptask.NewMockUseCase(ctrl)
+	useCase.EXPECT().GetList(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_, _ interface{}) (interface{}, error) {
+		panic("boom")
+	})
+	useCase.EXPECT().Each(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).DoAndReturn(func(_, _, _ interface{}) error {
+		panic("boom")
+	})
+
+	c := newClient(t, useCase)
+
+	_, err := c.List(context.Background(), request)
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, httperrors.ErrRecoverPanic.Error(), status.Convert(err).Message())
+
+	stream, err := c.Stream(context.Background(), request)
+	require.NoError(t, err)
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestServer_Shutdown(t *testing.T) {
+	s := New(getLogger(), "127.0.0.1:0", usecase.NewPeriodicTaskUC(getLogger()))
+	require.NoError(t, s.Start(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	assert.NoError(t, s.Shutdown(ctx))
+}
+
+func TestServer_Start(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer lis.Close()
+
+	// the address is in use.
+	s := New(getLogger(), lis.Addr().String(), usecase.NewPeriodicTaskUC(getLogger()))
+	assert.Error(t, s.Start(context.Background()))
+}
+
+func newClient(t *testing.T, useCase ptask.UseCase) ptaskpb.PeriodicTaskServiceClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+
+	s := New(getLogger(), "bufnet", useCase)
+
+	go func() { _ = s.Serve(lis) }()
+
+	t.Cleanup(func() { s.Shutdown(context.Background()) })
+
+	conn, err := grpc.Dial(
+		"bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return ptaskpb.NewPeriodicTaskServiceClient(conn)
+}
+
+func getLogger() logger.Logger {
+	l := &logrus.Logger{
+		Out:          io.Discard,
+		Hooks:        make(logrus.LevelHooks),
+		ReportCaller: false,
+		ExitFunc:     os.Exit,
+		Level:        logrus.DebugLevel,
+		Formatter:    &logrus.JSONFormatter{},
+	}
+
+	return log.New(l)
+}
@@ -0,0 +1,128 @@
+// Package tracing records OpenTelemetry spans of the requests the service handles and exports them with
+// OTLP, or to stdout for local testing.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/KarolosLykos/ptask"
+
+// Exporters of the spans.
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+var ErrUnknownExporter = errors.New("unknown trace exporter")
+
+// Attributes of the spans.
+const (
+	PeriodKey          = attribute.Key("ptask.period")
+	TimezoneKey        = attribute.Key("ptask.tz")
+	WindowKey          = attribute.Key("ptask.window_seconds")
+	InvocationPointKey = attribute.Key("ptask.invocation_point")
+	ResultCountKey     = attribute.Key("ptask.result_count")
+	SuppressedCountKey = attribute.Key("ptask.suppressed_count")
+)
+
+// Propagator reads and writes the W3C trace context of requests. It does not depend on Setup, so the
+// trace of callers is followed even when spans are not exported.
+var Propagator propagation.TextMapPropagator = propagation.TraceContext{}
+
+// Config selects the exporter of the spans and the ratio of traces sampled. Traces whose caller sampled
+// them, or not, follow its decision.
+type Config struct {
+	Exporter string
+	// Endpoint is the host:port of the OTLP gRPC collector; empty uses OTEL_EXPORTER_OTLP_ENDPOINT or
+	// localhost:4317.
+	Endpoint    string
+	SampleRatio float64
+	// Output is where the stdout exporter writes, os.Stdout by default.
+	Output io.Writer
+}
+
+// Setup installs the tracer provider described by cfg and returns the function flushing and stopping it.
+func Setup(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", "ptask"))),
+	}
+
+	switch cfg.Exporter {
+	case "", ExporterNone:
+	case ExporterStdout:
+		out := cfg.Output
+		if out == nil {
+			out = os.Stdout
+		}
+
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(out))
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	case ExporterOTLP:
+		var clientOpts []otlptracegrpc.Option
+		if cfg.Endpoint != "" {
+			clientOpts = append(clientOpts, otlptracegrpc.WithEndpoint(cfg.Endpoint), otlptracegrpc.WithInsecure())
+		}
+
+		exporter, err := otlptracegrpc.New(ctx, clientOpts...)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownExporter, cfg.Exporter)
+	}
+
+	provider := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Start starts a span named name, child of the one ctx carries.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartServer starts the span of a request sent with carrier, child of the span of its caller.
+func StartServer(
+	ctx context.Context,
+	carrier propagation.TextMapCarrier,
+	name string,
+	attrs ...attribute.KeyValue,
+) (context.Context, trace.Span) {
+	ctx = Propagator.Extract(ctx, carrier)
+
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attrs...))
+}
+
+// End records err on span, if any, and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
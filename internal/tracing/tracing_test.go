@@ -0,0 +1,81 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+func TestSetup(t *testing.T) {
+	tt := []struct {
+		name     string
+		cfg      Config
+		exported bool
+		err      error
+	}{
+		{name: "none", cfg: Config{Exporter: ExporterNone, SampleRatio: 1}},
+		{name: "stdout", cfg: Config{Exporter: ExporterStdout, SampleRatio: 1}, exported: true},
+		{name: "not sampled", cfg: Config{Exporter: ExporterStdout, SampleRatio: 0}},
+		{name: "unknown exporter", cfg: Config{Exporter: "zipkin"}, err: ErrUnknownExporter},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			tc.cfg.Output = out
+
+			shutdown, err := Setup(context.Background(), tc.cfg)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			ctx, span := Start(context.Background(), "parent", PeriodKey.String("1h"))
+			_, child := Start(ctx, "child")
+
+			// spans always get IDs, whether they are exported or not.
+			assert.True(t, span.SpanContext().IsValid())
+			assert.Equal(t, span.SpanContext().TraceID(), child.SpanContext().TraceID())
+
+			End(child, errors.New("boom"))
+			End(span, nil)
+
+			require.NoError(t, shutdown(context.Background()))
+
+			if tc.exported {
+				assert.Contains(t, out.String(), `"Name":"child"`)
+				assert.Contains(t, out.String(), `"Description":"boom"`)
+				assert.Contains(t, out.String(), `"Key":"ptask.period"`)
+			} else {
+				assert.Empty(t, out.String())
+			}
+		})
+	}
+}
+
+func TestStartServer(t *testing.T) {
+	shutdown, err := Setup(context.Background(), Config{SampleRatio: 0})
+	require.NoError(t, err)
+
+	defer func() { _ = shutdown(context.Background()) }()
+
+	carrier := propagation.MapCarrier{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}
+
+	_, span := StartServer(context.Background(), carrier, "GET /v1/ptlist")
+	defer span.End()
+
+	sc := span.SpanContext()
+
+	// the sampling decision of the caller is followed.
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", sc.TraceID().String())
+	assert.NotEqual(t, "00f067aa0ba902b7", sc.SpanID().String())
+	assert.True(t, sc.IsSampled())
+	assert.False(t, sc.IsRemote())
+}
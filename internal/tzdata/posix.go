@@ -0,0 +1,485 @@
+package tzdata
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidName   = errors.New("expected an IANA zone name such as Europe/Athens")
+	ErrInvalidOffset = errors.New("expected a fixed offset such as +03:00, -0500 or UTC-5")
+	ErrInvalidPOSIX  = errors.New("expected a POSIX TZ string such as EST5EDT,M3.2.0,M11.1.0")
+)
+
+// Parse loads tz as an IANA zone name from the active database, falling back to fixed offsets
+// and POSIX TZ strings. Errors report which of the forms tz was expected to be.
+func Parse(tz string) (*time.Location, error) {
+	loc, err := LoadLocation(tz)
+	if err == nil {
+		return loc, nil
+	}
+
+	switch {
+	case IsOffset(tz):
+		return ParseOffset(tz)
+	case IsPOSIX(tz):
+		return ParsePOSIX(tz)
+	default:
+		return nil, fmt.Errorf("%w: %v", ErrInvalidName, err)
+	}
+}
+
+const (
+	// defaultRules are applied to POSIX strings with a DST name but no rules, as glibc and Go do.
+	defaultRules = "M3.2.0,M11.1.0"
+	// defaultRuleTime is the local time of a rule without an explicit /time.
+	defaultRuleTime = 2 * 60 * 60
+	// firstTransitionYear and lastTransitionYear bound the transitions written to the synthesized zone,
+	// later ones are derived from the TZ string footer.
+	firstTransitionYear = 1970
+	lastTransitionYear  = 2037
+)
+
+var fixedOffset = regexp.MustCompile(`^(UTC|GMT)?([+-])(\d{1,2})(?::?(\d{2}))?$`)
+
+// ParseOffset parses fixed offsets such as "+03:00", "-0500", "UTC-5" or "GMT+05:30". Unlike POSIX strings,
+// the sign follows ISO 8601, so "UTC-5" is five hours behind UTC.
+func ParseOffset(s string) (*time.Location, error) {
+	m := fixedOffset.FindStringSubmatch(strings.ToUpper(s))
+	if m == nil {
+		return nil, ErrInvalidOffset
+	}
+
+	hours, _ := strconv.Atoi(m[3])
+	minutes, _ := strconv.Atoi(m[4])
+
+	if hours > 14 || minutes > 59 {
+		return nil, fmt.Errorf("%w: offset out of range", ErrInvalidOffset)
+	}
+
+	offset := hours*60*60 + minutes*60
+	if m[2] == "-" {
+		offset = -offset
+	}
+
+	return time.FixedZone(formatFixed(offset), offset), nil
+}
+
+// IsOffset reports whether s looks like a fixed offset rather than a zone name or a POSIX string.
+func IsOffset(s string) bool {
+	s = strings.ToUpper(s)
+
+	for _, prefix := range []string{"UTC", "GMT"} {
+		if strings.HasPrefix(s, prefix) && len(s) > len(prefix) && strings.ContainsAny(s[len(prefix):len(prefix)+1], "+-") {
+			return true
+		}
+	}
+
+	return s != "" && strings.ContainsAny(s[:1], "+-0123456789")
+}
+
+// IsPOSIX reports whether s looks like a POSIX TZ string rather than a zone name.
+func IsPOSIX(s string) bool {
+	zone, _, _ := strings.Cut(s, ",")
+
+	return strings.ContainsAny(zone, "0123456789<") && !strings.Contains(zone, "/")
+}
+
+// rule is the start or end of DST, e.g. M3.2.0/2 for 02:00 of the second Sunday of March.
+type rule struct {
+	kind  byte // 'J' for Julian days without Feb 29, 'N' for zero-based days, 'M' for month.week.day
+	day   int
+	week  int
+	month int
+	time  int
+}
+
+// posixTZ is a parsed POSIX TZ string. Offsets are seconds east of UTC, i.e. negated POSIX offsets.
+type posixTZ struct {
+	std, dst             string
+	stdOffset, dstOffset int
+	start, end           rule
+}
+
+// ParsePOSIX parses a POSIX TZ string such as "EST5EDT,M3.2.0,M11.1.0" or "<+03>-3" and returns a location
+// that applies its DST rules.
+func ParsePOSIX(s string) (*time.Location, error) {
+	tz, err := parsePOSIX(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if tz.dst == "" {
+		return time.FixedZone(tz.std, tz.stdOffset), nil
+	}
+
+	loc, err := time.LoadLocationFromTZData(s, tz.tzif(s))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPOSIX, err)
+	}
+
+	return loc, nil
+}
+
+func parsePOSIX(s string) (*posixTZ, error) {
+	p := &posixParser{s: s}
+	tz := &posixTZ{}
+
+	var err error
+
+	if tz.std, err = p.name(); err != nil {
+		return nil, err
+	}
+
+	offset, err := p.offset(24)
+	if err != nil {
+		return nil, err
+	}
+
+	tz.stdOffset = -offset
+
+	if p.done() {
+		return tz, nil
+	}
+
+	if tz.dst, err = p.name(); err != nil {
+		return nil, err
+	}
+
+	tz.dstOffset = tz.stdOffset + 60*60
+
+	if !p.done() && p.peek() != ',' {
+		if offset, err = p.offset(24); err != nil {
+			return nil, err
+		}
+
+		tz.dstOffset = -offset
+	}
+
+	if p.done() {
+		p = &posixParser{s: "," + defaultRules}
+	}
+
+	if tz.start, err = p.rule(); err != nil {
+		return nil, err
+	}
+
+	if tz.end, err = p.rule(); err != nil {
+		return nil, err
+	}
+
+	if !p.done() {
+		return nil, p.errorf("unexpected %q", p.s[p.i:])
+	}
+
+	return tz, nil
+}
+
+// transition is the UTC instant DST starts or ends.
+type transition struct {
+	at  int64
+	dst bool
+}
+
+// transitions returns the instants DST starts and ends in year, in chronological order.
+func (tz *posixTZ) transitions(year int) []transition {
+	start := transition{at: tz.start.at(year) - int64(tz.stdOffset), dst: true}
+	end := transition{at: tz.end.at(year) - int64(tz.dstOffset), dst: false}
+
+	if start.at < end.at {
+		return []transition{start, end}
+	}
+
+	return []transition{end, start}
+}
+
+// tzif synthesizes a version 2 TZif file holding the transitions of the supported years and the TZ string
+// as footer, so time.Location can apply the rules without a zoneinfo database.
+func (tz *posixTZ) tzif(s string) []byte {
+	var (
+		times []int64
+		types []byte
+	)
+
+	for year := firstTransitionYear; year <= lastTransitionYear; year++ {
+		for _, t := range tz.transitions(year) {
+			times = append(times, t.at)
+
+			if t.dst {
+				types = append(types, 1)
+			} else {
+				types = append(types, 0)
+			}
+		}
+	}
+
+	abbreviations := []byte(tz.std + "\x00" + tz.dst + "\x00")
+
+	buf := &bytes.Buffer{}
+
+	// version 1 header and data, skipped by readers supporting version 2.
+	writeHeader(buf, 0, 1, 1)
+	_ = binary.Write(buf, binary.BigEndian, int32(tz.stdOffset))
+	buf.Write([]byte{0, 0, 0})
+
+	// version 2 header and data.
+	writeHeader(buf, len(times), 2, len(abbreviations))
+
+	for _, t := range times {
+		_ = binary.Write(buf, binary.BigEndian, t)
+	}
+
+	buf.Write(types)
+
+	_ = binary.Write(buf, binary.BigEndian, int32(tz.stdOffset))
+	buf.Write([]byte{0, 0})
+	_ = binary.Write(buf, binary.BigEndian, int32(tz.dstOffset))
+	buf.Write([]byte{1, byte(len(tz.std) + 1)})
+	buf.Write(abbreviations)
+
+	buf.WriteString("\n" + s + "\n")
+
+	return buf.Bytes()
+}
+
+func writeHeader(buf *bytes.Buffer, timecnt, typecnt, charcnt int) {
+	buf.WriteString("TZif2")
+	buf.Write(make([]byte, 15))
+
+	// isutcnt, isstdcnt, leapcnt, timecnt, typecnt, charcnt.
+	for _, n := range []int{0, 0, 0, timecnt, typecnt, charcnt} {
+		_ = binary.Write(buf, binary.BigEndian, uint32(n))
+	}
+}
+
+// at returns the seconds since the epoch of the rule in year, as local time of the zone it applies to.
+func (r rule) at(year int) int64 {
+	jan1 := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	var day int
+
+	switch r.kind {
+	case 'J':
+		day = r.day - 1
+		if isLeap(year) && r.day >= 60 {
+			day++
+		}
+	case 'N':
+		day = r.day
+	default:
+		first := time.Date(year, time.Month(r.month), 1, 0, 0, 0, 0, time.UTC)
+		d := (r.day - int(first.Weekday()) + 7) % 7
+		d += (r.week - 1) * 7
+
+		for daysIn := first.AddDate(0, 1, -1).Day(); d >= daysIn; {
+			d -= 7
+		}
+
+		day = first.YearDay() - 1 + d
+	}
+
+	return jan1.Unix() + int64(day)*24*60*60 + int64(r.time)
+}
+
+func isLeap(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+func formatFixed(offset int) string {
+	sign := '+'
+	if offset < 0 {
+		sign, offset = '-', -offset
+	}
+
+	return fmt.Sprintf("UTC%c%02d:%02d", sign, offset/3600, offset/60%60)
+}
+
+type posixParser struct {
+	s string
+	i int
+}
+
+func (p *posixParser) done() bool {
+	return p.i >= len(p.s)
+}
+
+func (p *posixParser) peek() byte {
+	return p.s[p.i]
+}
+
+func (p *posixParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s at position %d", ErrInvalidPOSIX, fmt.Sprintf(format, args...), p.i)
+}
+
+// name parses a zone abbreviation, either 3 or more letters or <...> quoted.
+func (p *posixParser) name() (string, error) {
+	start := p.i
+
+	if !p.done() && p.peek() == '<' {
+		end := strings.IndexByte(p.s[p.i:], '>')
+		if end < 0 {
+			return "", p.errorf("unterminated quoted name")
+		}
+
+		name := p.s[p.i+1 : p.i+end]
+		p.i += end + 1
+
+		if len(name) < 3 {
+			p.i = start
+
+			return "", p.errorf("name %q shorter than 3 characters", name)
+		}
+
+		return name, nil
+	}
+
+	for !p.done() && (p.peek() >= 'a' && p.peek() <= 'z' || p.peek() >= 'A' && p.peek() <= 'Z') {
+		p.i++
+	}
+
+	if p.i-start < 3 {
+		p.i = start
+
+		return "", p.errorf("expected a name of 3 or more letters")
+	}
+
+	return p.s[start:p.i], nil
+}
+
+// offset parses [+-]hh[:mm[:ss]] up to maxHours and returns it in seconds.
+func (p *posixParser) offset(maxHours int) (int, error) {
+	sign := 1
+
+	if !p.done() && (p.peek() == '+' || p.peek() == '-') {
+		if p.peek() == '-' {
+			sign = -1
+		}
+
+		p.i++
+	}
+
+	hours, err := p.number(maxHours)
+	if err != nil {
+		return 0, err
+	}
+
+	seconds := hours * 60 * 60
+
+	for _, unit := range []int{60, 1} {
+		if p.done() || p.peek() != ':' {
+			break
+		}
+
+		p.i++
+
+		n, err := p.number(59)
+		if err != nil {
+			return 0, err
+		}
+
+		seconds += n * unit
+	}
+
+	return sign * seconds, nil
+}
+
+// number parses a decimal number of at most limit.
+func (p *posixParser) number(limit int) (int, error) {
+	start := p.i
+
+	for !p.done() && p.peek() >= '0' && p.peek() <= '9' {
+		p.i++
+	}
+
+	if start == p.i {
+		return 0, p.errorf("expected a number")
+	}
+
+	n, _ := strconv.Atoi(p.s[start:p.i])
+	if n > limit {
+		p.i = start
+
+		return 0, p.errorf("%d is greater than %d", n, limit)
+	}
+
+	return n, nil
+}
+
+// rule parses ,Jn[/time], ,n[/time] or ,Mm.w.d[/time].
+func (p *posixParser) rule() (rule, error) {
+	if p.done() || p.peek() != ',' {
+		return rule{}, p.errorf("expected a ,start,end rule")
+	}
+
+	p.i++
+
+	r := rule{time: defaultRuleTime}
+
+	var err error
+
+	switch {
+	case !p.done() && p.peek() == 'J':
+		p.i++
+		r.kind = 'J'
+
+		if r.day, err = p.number(365); err != nil {
+			return rule{}, err
+		}
+
+		if r.day == 0 {
+			return rule{}, p.errorf("Julian day must be between 1 and 365")
+		}
+	case !p.done() && p.peek() == 'M':
+		p.i++
+		r.kind = 'M'
+
+		if r.month, err = p.number(12); err != nil {
+			return rule{}, err
+		}
+
+		if r.month == 0 {
+			return rule{}, p.errorf("month must be between 1 and 12")
+		}
+
+		for _, field := range []struct {
+			dst   *int
+			limit int
+		}{{&r.week, 5}, {&r.day, 6}} {
+			if p.done() || p.peek() != '.' {
+				return rule{}, p.errorf("expected Mm.w.d")
+			}
+
+			p.i++
+
+			if *field.dst, err = p.number(field.limit); err != nil {
+				return rule{}, err
+			}
+		}
+
+		if r.week == 0 {
+			return rule{}, p.errorf("week must be between 1 and 5")
+		}
+	default:
+		r.kind = 'N'
+
+		if r.day, err = p.number(365); err != nil {
+			return rule{}, err
+		}
+	}
+
+	if !p.done() && p.peek() == '/' {
+		p.i++
+
+		if r.time, err = p.offset(167); err != nil {
+			return rule{}, err
+		}
+	}
+
+	return r, nil
+}
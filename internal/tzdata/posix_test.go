@@ -0,0 +1,164 @@
+package tzdata
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOffset(t *testing.T) {
+	tt := []struct {
+		offset  string
+		seconds int
+		name    string
+		err     error
+	}{
+		{offset: "+03:00", seconds: 3 * 3600, name: "UTC+03:00"},
+		{offset: "-0500", seconds: -5 * 3600, name: "UTC-05:00"},
+		{offset: "UTC-5", seconds: -5 * 3600, name: "UTC-05:00"},
+		{offset: "gmt+05:30", seconds: 5*3600 + 30*60, name: "UTC+05:30"},
+		{offset: "+14", seconds: 14 * 3600, name: "UTC+14:00"},
+		{offset: "+15:00", err: ErrInvalidOffset},
+		{offset: "+03:60", err: ErrInvalidOffset},
+		{offset: "0300", err: ErrInvalidOffset},
+		{offset: "UTC+", err: ErrInvalidOffset},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.offset, func(t *testing.T) {
+			loc, err := ParseOffset(tc.offset)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			name, offset := time.Date(2021, 7, 14, 0, 0, 0, 0, loc).Zone()
+			assert.Equal(t, tc.seconds, offset)
+			assert.Equal(t, tc.name, name)
+		})
+	}
+}
+
+func TestParsePOSIX(t *testing.T) {
+	newYork, err := LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	sydney, err := LoadLocation("Australia/Sydney")
+	require.NoError(t, err)
+
+	tt := []struct {
+		name      string
+		tz        string
+		reference *time.Location
+	}{
+		{name: "northern hemisphere", tz: "EST5EDT,M3.2.0,M11.1.0", reference: newYork},
+		{name: "default rules", tz: "EST5EDT", reference: newYork},
+		{name: "explicit times", tz: "EST+5EDT+4,M3.2.0/2:00:00,M11.1.0/02", reference: newYork},
+		{name: "southern hemisphere", tz: "AEST-10AEDT,M10.1.0,M4.1.0/3", reference: sydney},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			loc, err := ParsePOSIX(tc.tz)
+			require.NoError(t, err)
+
+			// compare every 6 hours of a few years, since the current rules, within and beyond the written transitions.
+			for _, year := range []int{2010, 2021, 2024, 2037, 2050} {
+				for at := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC); at.Year() == year; at = at.Add(6 * time.Hour) {
+					name, offset := at.In(loc).Zone()
+					expectedName, expectedOffset := at.In(tc.reference).Zone()
+
+					require.Equal(t, expectedOffset, offset, "%s at %s", tc.tz, at)
+					require.Equal(t, expectedName, name, "%s at %s", tc.tz, at)
+				}
+			}
+		})
+	}
+}
+
+func TestParsePOSIX_Rules(t *testing.T) {
+	// Julian day 60 is March 1st, even in leap years, whereas zero-based day 59 is Feb 29th in leap years.
+	loc, err := ParsePOSIX("AAA0BBB,J60/0,J300/0")
+	require.NoError(t, err)
+	assert.Equal(t, "AAA", abbreviation(time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC), loc))
+	assert.Equal(t, "BBB", abbreviation(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC), loc))
+
+	loc, err = ParsePOSIX("AAA0BBB,59/0,299/0")
+	require.NoError(t, err)
+	assert.Equal(t, "AAA", abbreviation(time.Date(2024, 2, 28, 12, 0, 0, 0, time.UTC), loc))
+	assert.Equal(t, "BBB", abbreviation(time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC), loc))
+
+	// the fifth week means the last one of the month.
+	loc, err = ParsePOSIX("<+00>0<+01>,M3.5.0/1,M10.5.0")
+	require.NoError(t, err)
+	assert.Equal(t, "+00", abbreviation(time.Date(2021, 3, 28, 0, 59, 0, 0, time.UTC), loc))
+	assert.Equal(t, "+01", abbreviation(time.Date(2021, 3, 28, 1, 0, 0, 0, time.UTC), loc))
+
+	loc, err = ParsePOSIX("<+03>-3")
+	require.NoError(t, err)
+	assert.Equal(t, "+03", abbreviation(time.Date(2021, 7, 14, 0, 0, 0, 0, time.UTC), loc))
+}
+
+func TestParsePOSIX_Errors(t *testing.T) {
+	for _, tz := range []string{
+		"ES5",
+		"EST",
+		"EST25",
+		"<E>5",
+		"<EST5",
+		"EST5EDT,M3.2.0",
+		"EST5EDT,M13.2.0,M11.1.0",
+		"EST5EDT,M3.0.0,M11.1.0",
+		"EST5EDT,M3.2.7,M11.1.0",
+		"EST5EDT,M3.2,M11.1.0",
+		"EST5EDT,J0,J300",
+		"EST5EDT,J60,J300/168",
+		"EST5EDT,M3.2.0,M11.1.0 trailing",
+	} {
+		t.Run(tz, func(t *testing.T) {
+			_, err := ParsePOSIX(tz)
+			assert.ErrorIs(t, err, ErrInvalidPOSIX)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tt := []struct {
+		tz   string
+		name string
+		err  error
+	}{
+		{tz: "Europe/Athens", name: "Europe/Athens"},
+		{tz: "EST5EDT", name: "EST5EDT"},
+		{tz: "UTC-5", name: "UTC-05:00"},
+		{tz: "CET-1CEST,M3.5.0,M10.5.0/3", name: "CET-1CEST,M3.5.0,M10.5.0/3"},
+		{tz: "Europe/Nowhere", err: ErrInvalidName},
+		{tz: "+25:00", err: ErrInvalidOffset},
+		{tz: "XYZ5ABC,M3", err: ErrInvalidPOSIX},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.tz, func(t *testing.T) {
+			loc, err := Parse(tc.tz)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.name, loc.String())
+		})
+	}
+}
+
+func abbreviation(at time.Time, loc *time.Location) string {
+	name, _ := at.In(loc).Zone()
+
+	return name
+}
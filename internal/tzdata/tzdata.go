@@ -0,0 +1,212 @@
+package tzdata
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	SourceEmbedded = "embedded"
+	SourceOverride = "override"
+)
+
+var (
+	ErrUnknownZone    = errors.New("unknown time zone")
+	ErrInvalidBundle  = errors.New("invalid tzdata bundle")
+	versionEntries    = []string{"VERSION", "+VERSION", "version"}
+	tzdataZiEntryName = "tzdata.zi"
+)
+
+// files holds the embedded zoneinfo.zip, its release being in its +VERSION entry. To upgrade it, copy
+// $(go env GOROOT)/lib/time/zoneinfo.zip of a Go release shipping a newer tzdata and add the DATA release of
+// $(go env GOROOT)/lib/time/update.bash to it, e.g. "echo 2026c > +VERSION && zip -0 -X zoneinfo.zip +VERSION".
+//
+//go:embed zoneinfo.zip
+var files embed.FS
+
+// bundle is a zoneinfo database, i.e. the zones of a zoneinfo.zip along with its release.
+type bundle struct {
+	version   string
+	source    string
+	zones     map[string][]byte
+	locations sync.Map
+}
+
+var active atomic.Pointer[bundle]
+
+func init() {
+	data, err := files.ReadFile("zoneinfo.zip")
+	if err != nil {
+		panic(err)
+	}
+
+	b, err := newBundle(data, SourceEmbedded)
+	if err != nil {
+		panic(err)
+	}
+
+	active.Store(b)
+}
+
+// LoadOverride replaces the embedded database with the zoneinfo.zip at path, so zone rule changes
+// can be picked up without a rebuild. The release is read from a VERSION entry, or the header of
+// tzdata.zi, when the bundle has one.
+func LoadOverride(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	b, err := newBundle(data, fmt.Sprintf("%s:%s", SourceOverride, path))
+	if err != nil {
+		return err
+	}
+
+	active.Store(b)
+
+	return nil
+}
+
+// Version returns the IANA release of the active database.
+func Version() string {
+	return active.Load().version
+}
+
+// Source returns where the active database was loaded from.
+func Source() string {
+	return active.Load().source
+}
+
+// LoadLocation mirrors time.LoadLocation but looks zones up in the active database
+// instead of the host, so results do not depend on what the host ships. For the same
+// reason "Local", the zone of the host, is unknown.
+func LoadLocation(name string) (*time.Location, error) {
+	switch name {
+	case "", "UTC":
+		return time.UTC, nil
+	}
+
+	b := active.Load()
+
+	if loc, ok := b.locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+
+	data, ok := b.zones[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %s", ErrUnknownZone, name)
+	}
+
+	loc, err := time.LoadLocationFromTZData(name, data)
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: %v", ErrUnknownZone, name, err)
+	}
+
+	b.locations.Store(name, loc)
+
+	return loc, nil
+}
+
+// Names lists the zone names of the active database.
+func Names() []string {
+	b := active.Load()
+
+	names := make([]string, 0, len(b.zones))
+	for name := range b.zones {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func newBundle(data []byte, source string) (*bundle, error) {
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+	}
+
+	b := &bundle{version: "unknown", source: source, zones: map[string][]byte{}}
+
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+
+		content, err := readFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidBundle, err)
+		}
+
+		switch {
+		case isVersionEntry(f.Name):
+			b.version = strings.TrimSpace(string(content))
+		case f.Name == tzdataZiEntryName:
+			if version := tzdataZiVersion(content); version != "" {
+				b.version = version
+			}
+		case isZoneName(f.Name) && bytes.HasPrefix(content, []byte("TZif")):
+			b.zones[f.Name] = content
+		}
+	}
+
+	if len(b.zones) == 0 {
+		return nil, fmt.Errorf("%w: no zones found", ErrInvalidBundle)
+	}
+
+	return b, nil
+}
+
+func readFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return io.ReadAll(rc)
+}
+
+func isVersionEntry(name string) bool {
+	for _, entry := range versionEntries {
+		if name == entry {
+			return true
+		}
+	}
+
+	return false
+}
+
+// tzdataZiVersion reads the "# version 2024a" header of a tzdata.zi file.
+func tzdataZiVersion(content []byte) string {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	if scanner.Scan() {
+		if line := scanner.Text(); strings.HasPrefix(line, "# version ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "# version "))
+		}
+	}
+
+	return ""
+}
+
+// isZoneName filters out the files of a zoneinfo database that are not zones.
+func isZoneName(name string) bool {
+	switch name {
+	case "localtime", "posixrules", "Factory", "leapseconds", "leap-seconds.list", "SECURITY", "README":
+		return false
+	}
+
+	return !strings.Contains(name, ".") && strings.ToUpper(name[:1]) == name[:1]
+}
@@ -0,0 +1,108 @@
+package tzdata
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNames(t *testing.T) {
+	names := Names()
+
+	assert.Contains(t, names, "Europe/Athens")
+	assert.Contains(t, names, "America/Los_Angeles")
+	assert.NotContains(t, names, "posixrules")
+	assert.NotContains(t, names, "zone.tab")
+	assert.IsIncreasing(t, names)
+}
+
+func TestLoadLocation(t *testing.T) {
+	tt := []struct {
+		name     string
+		location string
+		err      error
+	}{
+		{name: "empty", location: ""},
+		{name: "UTC", location: "UTC"},
+		{name: "Local", location: "Local", err: ErrUnknownZone},
+		{name: "zone", location: "Europe/Athens"},
+		{name: "unknown", location: "Europe/Nowhere", err: ErrUnknownZone},
+		{name: "path traversal", location: "../../etc/passwd", err: ErrUnknownZone},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			loc, err := LoadLocation(tc.location)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			expected, err := time.LoadLocation(tc.location)
+			require.NoError(t, err)
+			assert.Equal(t, expected.String(), loc.String())
+		})
+	}
+}
+
+func TestEmbeddedVersion(t *testing.T) {
+	embedded := active.Load()
+
+	// the release is read from the +VERSION entry of the embedded zoneinfo.zip, which is the one
+	// of the Go release it was copied from.
+	assert.Regexp(t, `^\d{4}[a-z]$`, embedded.version)
+	assert.Equal(t, SourceEmbedded, embedded.source)
+}
+
+func TestLoadOverride(t *testing.T) {
+	embedded := active.Load()
+	defer active.Store(embedded)
+
+	athens, ok := embedded.zones["Europe/Athens"]
+	require.True(t, ok)
+
+	path := filepath.Join(t.TempDir(), "zoneinfo.zip")
+	writeZip(t, path, map[string][]byte{"Europe/Athens": athens, "VERSION": []byte("2099z\n")})
+
+	require.NoError(t, LoadOverride(path))
+	assert.Equal(t, "2099z", Version())
+	assert.Equal(t, SourceOverride+":"+path, Source())
+	assert.Equal(t, []string{"Europe/Athens"}, Names())
+
+	_, err := LoadLocation("America/Los_Angeles")
+	assert.ErrorIs(t, err, ErrUnknownZone)
+
+	empty := filepath.Join(t.TempDir(), "empty.zip")
+	writeZip(t, empty, map[string][]byte{"VERSION": []byte("2099z")})
+	assert.ErrorIs(t, LoadOverride(empty), ErrInvalidBundle)
+
+	assert.Error(t, LoadOverride(filepath.Join(t.TempDir(), "missing.zip")))
+}
+
+func writeZip(t *testing.T, path string, entries map[string][]byte) {
+	t.Helper()
+
+	f, err := os.Create(path)
+	require.NoError(t, err)
+
+	defer f.Close()
+
+	w := zip.NewWriter(f)
+	for name, content := range entries {
+		e, err := w.Create(name)
+		require.NoError(t, err)
+
+		_, err = e.Write(content)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, w.Close())
+}
@@ -2,13 +2,110 @@ package httperrors
 
 import (
 	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/KarolosLykos/ptask/pkg/schedule"
 )
 
 var (
-	ErrRecoverPanic      = errors.New("recovering from error")
-	ErrInternalServer    = errors.New("something went wrong")
-	ErrInvalidPeriod     = errors.New("invalid period")
-	ErrInvalidTimezone   = errors.New("invalid timezone")
-	ErrInvalidStartPoint = errors.New("invalid start point")
-	ErrInvalidEndPoint   = errors.New("invalid end point")
+	ErrRecoverPanic        = New("recovered_panic", http.StatusInternalServerError, "recovering from error")
+	ErrInternalServer      = New("internal", http.StatusInternalServerError, "something went wrong")
+	ErrInvalidPeriod       = New("invalid_period", http.StatusBadRequest, schedule.ErrInvalidPeriod.Error())
+	ErrInvalidTimezone     = New("invalid_timezone", http.StatusBadRequest, "invalid timezone")
+	ErrInvalidStartPoint   = New("invalid_start_point", http.StatusBadRequest, "invalid start point")
+	ErrInvalidEndPoint     = New("invalid_end_point", http.StatusBadRequest, "invalid end point")
+	ErrInvalidBlackout     = New("invalid_blackout", http.StatusBadRequest, "invalid blackout")
+	ErrUnknownBlackout     = New("unknown_blackout", http.StatusBadRequest, "unknown blackout set")
+	ErrInvalidExpression   = New("invalid_expression", http.StatusBadRequest, "invalid expression")
+	ErrInvalidSchedules    = New("invalid_schedules", http.StatusBadRequest, "invalid schedules")
+	ErrUnsupportedLanguage = New("unsupported_language", http.StatusBadRequest, "unsupported language")
+	ErrUnparsableSchedule  = New("unparsable_schedule", http.StatusBadRequest, "unparsable schedule")
+	ErrInvalidCount        = New("invalid_count", http.StatusBadRequest, "invalid count")
+	ErrInvalidTimestamp    = New("invalid_timestamp", http.StatusBadRequest, "invalid timestamp")
+	ErrNotReady            = New("not_ready", http.StatusServiceUnavailable, "service not ready")
+	ErrUnauthorized        = New("unauthorized", http.StatusUnauthorized, "unauthorized")
+	ErrInvalidLogLevel     = New("invalid_log_level", http.StatusBadRequest, "invalid log level")
+	ErrInvalidDuration     = New("invalid_duration", http.StatusBadRequest, "invalid duration")
 )
+
+// Error is an error of the service. Code identifies its kind, Message is what clients are told, and Cause
+// is the internal reason, logged but never returned to clients of errors answered with 500.
+type Error struct {
+	Code    string
+	Status  int
+	Message string
+	Cause   error
+}
+
+// New returns an Error without a cause, meant to be wrapped.
+func New(code string, status int, message string) *Error {
+	return &Error{Code: code, Status: status, Message: message}
+}
+
+// Wrap returns e caused by cause, or e itself when cause is nil.
+func Wrap(e *Error, cause error) error {
+	if cause == nil {
+		return e
+	}
+
+	return &Error{Code: e.Code, Status: e.Status, Message: e.Message, Cause: cause}
+}
+
+// Wrapf returns e caused by the formatted reason.
+func Wrapf(e *Error, format string, args ...interface{}) error {
+	return Wrap(e, fmt.Errorf(format, args...))
+}
+
+// WrapPeriod reports an error of the schedule package as ErrInvalidPeriod, keeping its reason as the cause.
+func WrapPeriod(err error) error {
+	if err == nil || !errors.Is(err, schedule.ErrInvalidPeriod) {
+		return err
+	}
+
+	if err == schedule.ErrInvalidPeriod {
+		return ErrInvalidPeriod
+	}
+
+	return Wrap(ErrInvalidPeriod, errors.New(strings.TrimPrefix(err.Error(), schedule.ErrInvalidPeriod.Error()+":")))
+}
+
+func (e *Error) Error() string {
+	if e.Cause == nil {
+		return e.Message
+	}
+
+	return e.Message + ":" + e.Cause.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
+
+// Is reports whether target is an Error of the same code, so wrapped errors match their sentinel.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+
+	return ok && t.Code == e.Code
+}
+
+// From returns the Error err is or wraps. Any other error is an ErrInternalServer caused by err.
+func From(err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+
+	return &Error{Code: ErrInternalServer.Code, Status: ErrInternalServer.Status, Message: ErrInternalServer.Message, Cause: err}
+}
+
+// StatusCode returns the HTTP status code err is answered with.
+func StatusCode(err error) int {
+	return From(err).Status
+}
+
+// Message returns the message clients are told about err, which never carries internal details.
+func Message(err error) string {
+	return From(err).Message
+}
@@ -0,0 +1,51 @@
+package httperrors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/KarolosLykos/ptask/pkg/schedule"
+)
+
+func TestFrom(t *testing.T) {
+	_, errPeriod := schedule.ParsePeriod("0d")
+
+	tt := []struct {
+		name    string
+		err     error
+		code    string
+		status  int
+		message string
+	}{
+		{name: "sentinel", err: ErrInvalidTimezone, code: "invalid_timezone", status: http.StatusBadRequest, message: "invalid timezone"},
+		{name: "wrapped", err: Wrapf(ErrInvalidCount, "expected 1 to %d", 100), code: "invalid_count", status: http.StatusBadRequest, message: "invalid count"},
+		{name: "wrapped twice", err: fmt.Errorf("sync: %w", Wrap(ErrInvalidSchedules, errors.New("duplicate"))), code: "invalid_schedules", status: http.StatusBadRequest, message: "invalid schedules"},
+		{name: "schedule", err: WrapPeriod(errPeriod), code: "invalid_period", status: http.StatusBadRequest, message: "invalid period"},
+		{name: "unknown", err: errors.New("dial tcp: connection refused"), code: "internal", status: http.StatusInternalServerError, message: "something went wrong"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			e := From(tc.err)
+
+			assert.Equal(t, tc.code, e.Code)
+			assert.Equal(t, tc.status, StatusCode(tc.err))
+			assert.Equal(t, tc.message, Message(tc.err))
+		})
+	}
+}
+
+func TestError_Is(t *testing.T) {
+	err := Wrapf(ErrInvalidBlackout, "end must be after start")
+
+	assert.ErrorIs(t, err, ErrInvalidBlackout)
+	assert.NotErrorIs(t, err, ErrInvalidTimezone)
+	assert.Equal(t, "invalid blackout:end must be after start", err.Error())
+
+	assert.Equal(t, ErrInvalidBlackout, Wrap(ErrInvalidBlackout, nil))
+	assert.Equal(t, "invalid period:value must be positive", WrapPeriod(fmt.Errorf("%w:value must be positive", schedule.ErrInvalidPeriod)).Error())
+}
@@ -0,0 +1,85 @@
+package httperrors
+
+import (
+	"errors"
+	"strings"
+)
+
+// FieldError is an invalid parameter of a request, along with an example of a valid value.
+type FieldError struct {
+	Param   string `json:"param,omitempty"`
+	Reason  string `json:"reason"`
+	Example string `json:"example,omitempty"`
+
+	err error
+}
+
+// ValidationError collects every invalid parameter of a request instead of the first one. It unwraps to
+// the error of its first parameter, so it is answered with the same status and message that parameter
+// alone would be.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+// Invalid returns a ValidationError reporting param, or nil when err is nil.
+func Invalid(param, example string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &ValidationError{Fields: []FieldError{{Param: param, Reason: err.Error(), Example: example, err: err}}}
+}
+
+// Collect merges the errors of the validations of a request into one ValidationError, or returns nil when
+// they all passed. Errors that are not ValidationErrors are reported without a parameter.
+func Collect(errs ...error) error {
+	v := &ValidationError{}
+
+	for _, err := range errs {
+		var ve *ValidationError
+
+		switch {
+		case err == nil:
+		case errors.As(err, &ve):
+			v.Fields = append(v.Fields, ve.Fields...)
+		default:
+			v.Fields = append(v.Fields, FieldError{Reason: err.Error(), err: err})
+		}
+	}
+
+	if len(v.Fields) == 0 {
+		return nil
+	}
+
+	return v
+}
+
+func (v *ValidationError) Error() string {
+	reasons := make([]string, 0, len(v.Fields))
+
+	for _, f := range v.Fields {
+		if f.Param == "" {
+			reasons = append(reasons, f.Reason)
+			continue
+		}
+
+		reasons = append(reasons, f.Param+": "+f.Reason)
+	}
+
+	return strings.Join(reasons, "; ")
+}
+
+func (v *ValidationError) Unwrap() error {
+	return v.Fields[0].err
+}
+
+// Is reports whether any of the parameters failed with target.
+func (v *ValidationError) Is(target error) bool {
+	for _, f := range v.Fields {
+		if errors.Is(f.err, target) {
+			return true
+		}
+	}
+
+	return false
+}
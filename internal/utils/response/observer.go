@@ -0,0 +1,40 @@
+package response
+
+import (
+	"net/http"
+
+	"github.com/KarolosLykos/ptask/internal/utils/httperrors"
+)
+
+// Observer is told about the responses written through a ResponseWriter implementing it, e.g. to measure
+// them. Writers wrapping one are looked through with their Unwrap method.
+type Observer interface {
+	ObserveError(e *httperrors.Error)
+	ObserveOccurrences(n int)
+}
+
+// ObserveOccurrences reports the number of occurrences a response carries to the Observer of w, if any.
+func ObserveOccurrences(w http.ResponseWriter, n int) {
+	if o := observer(w); o != nil {
+		o.ObserveOccurrences(n)
+	}
+}
+
+func observeError(w http.ResponseWriter, e *httperrors.Error) {
+	if o := observer(w); o != nil {
+		o.ObserveError(e)
+	}
+}
+
+func observer(w http.ResponseWriter) Observer {
+	for {
+		switch t := w.(type) {
+		case Observer:
+			return t
+		case interface{ Unwrap() http.ResponseWriter }:
+			w = t.Unwrap()
+		default:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/KarolosLykos/ptask/internal/constants"
+	"github.com/KarolosLykos/ptask/internal/utils/httperrors"
+)
+
+// Problem is an RFC 7807 problem details object. Errors lists every invalid parameter of a request.
+type Problem struct {
+	Type      string                  `json:"type"`
+	Title     string                  `json:"title"`
+	Status    int                     `json:"status"`
+	Detail    string                  `json:"detail,omitempty"`
+	Errors    []httperrors.FieldError `json:"errors,omitempty"`
+	Details   interface{}             `json:"details,omitempty"`
+	RequestID string                  `json:"request_id,omitempty"`
+}
+
+// problemWriter marks the responses whose errors are written as problem details, once enabled.
+type problemWriter struct {
+	http.ResponseWriter
+	enabled bool
+}
+
+// Unwrap returns the wrapped ResponseWriter.
+func (p *problemWriter) Unwrap() http.ResponseWriter {
+	return p.ResponseWriter
+}
+
+// ProblemMode lets Problems, applied further down the chain e.g. on a subrouter, switch the errors of the
+// middlewares in between to problem details too, such as the ones RecoverPanic writes. It must wrap them.
+func ProblemMode(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(&problemWriter{ResponseWriter: w}, r)
+	})
+}
+
+// Problems makes the handlers of a router write their errors as problem details instead of the
+// status/error envelope, and the middlewares ProblemMode wraps too.
+func Problems(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if p := findProblemWriter(w); p != nil {
+			p.enabled = true
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		next.ServeHTTP(&problemWriter{ResponseWriter: w, enabled: true}, r)
+	})
+}
+
+// findProblemWriter returns the problemWriter w is or wraps, looking through writers with their Unwrap
+// method.
+func findProblemWriter(w http.ResponseWriter) *problemWriter {
+	for {
+		switch t := w.(type) {
+		case *problemWriter:
+			return t
+		case interface{ Unwrap() http.ResponseWriter }:
+			w = t.Unwrap()
+		default:
+			return nil
+		}
+	}
+}
+
+// problems reports whether the errors written to w are problem details.
+func problems(w http.ResponseWriter) bool {
+	p := findProblemWriter(w)
+
+	return p != nil && p.enabled
+}
+
+// NegotiateProblems applies Problems to the requests accepting problem details.
+func NegotiateProblems(next http.Handler) http.Handler {
+	problems := Problems(next)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.Header.Get("Accept"), constants.ProblemContentType) {
+			problems.ServeHTTP(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// NewProblem describes err. Its type is derived from the code of the error, so it stays the same whatever
+// the cause. Errors answered with 500 are typed about:blank and never detail their cause.
+func NewProblem(err error) *Problem {
+	e := httperrors.From(err)
+
+	var v *httperrors.ValidationError
+	if errors.As(err, &v) {
+		return &Problem{
+			Type:   constants.ProblemTypePrefix + "invalid-parameters",
+			Title:  "invalid parameters",
+			Status: e.Status,
+			Detail: v.Error(),
+			Errors: v.Fields,
+		}
+	}
+
+	if e.Status >= http.StatusInternalServerError {
+		return &Problem{Type: "about:blank", Title: http.StatusText(e.Status), Status: e.Status, Detail: e.Message}
+	}
+
+	return &Problem{
+		Type:   constants.ProblemTypePrefix + strings.ReplaceAll(e.Code, "_", "-"),
+		Title:  e.Message,
+		Status: e.Status,
+		Detail: err.Error(),
+	}
+}
+
+func writeProblem(w http.ResponseWriter, err error, details interface{}) {
+	problem := NewProblem(err)
+	problem.Details = details
+	problem.RequestID = w.Header().Get(constants.RequestIDHeader)
+
+	w.Header().Set("Content-Type", constants.ProblemContentType)
+	w.WriteHeader(problem.Status)
+
+	p, _ := json.Marshal(problem)
+
+	_, _ = w.Write(p)
+}
@@ -2,17 +2,20 @@ package response
 
 import (
 	"encoding/json"
-	"errors"
 	"net/http"
 
 	"github.com/KarolosLykos/ptask/internal/constants"
 	"github.com/KarolosLykos/ptask/internal/utils/httperrors"
 )
 
+// Response is the status/error envelope of every response. Code identifies the kind of an error, it stays the
+// same whatever its message, so clients match on it.
 type Response struct {
-	Status string      `json:"status"`
-	Error  string      `json:"error,omitempty"`
-	Data   interface{} `json:"data,omitempty"`
+	Status    string      `json:"status"`
+	Error     string      `json:"error,omitempty"`
+	Code      string      `json:"code,omitempty"`
+	RequestID string      `json:"request_id,omitempty"`
+	Data      interface{} `json:"data,omitempty"`
 }
 
 func Success(w http.ResponseWriter, statusCode int, payload interface{}) {
@@ -31,24 +34,26 @@ func Success(w http.ResponseWriter, statusCode int, payload interface{}) {
 }
 
 func Error(w http.ResponseWriter, err error) {
-	statusCode := http.StatusInternalServerError
-	errMsg := err.Error()
+	ErrorWithDetails(w, err, nil)
+}
 
-	if errU := errors.Unwrap(err); errU != nil {
-		errMsg = errU.Error()
-	}
+// ErrorWithDetails writes an error response carrying structured details in its data field, or as problem
+// details behind Problems.
+func ErrorWithDetails(w http.ResponseWriter, err error, details interface{}) {
+	e := httperrors.From(err)
 
-	if errors.Is(err, httperrors.ErrInvalidPeriod) ||
-		errors.Is(err, httperrors.ErrInvalidTimezone) ||
-		errors.Is(err, httperrors.ErrInvalidStartPoint) ||
-		errors.Is(err, httperrors.ErrInvalidEndPoint) {
-		statusCode = http.StatusBadRequest
+	observeError(w, e)
+
+	if problems(w) {
+		writeProblem(w, err, details)
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
+	w.WriteHeader(e.Status)
 
-	res := &Response{Status: constants.StatusError, Error: errMsg}
+	// the request ID is echoed by the middleware, errors carry it too so it can be reported.
+	res := &Response{Status: constants.StatusError, Error: e.Message, Code: e.Code, RequestID: w.Header().Get(constants.RequestIDHeader), Data: details}
 
 	p, _ := json.Marshal(res)
 
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/KarolosLykos/ptask/internal/constants"
 	"github.com/KarolosLykos/ptask/internal/utils/httperrors"
 )
 
@@ -52,7 +53,7 @@ func TestResponse_Error(t *testing.T) {
 		response string
 	}{
 		{name: "wrapped error", status: http.StatusInternalServerError, err: fmt.Errorf("%w:%v", httperrors.ErrInternalServer, errors.New("wrapped error"))},
-		{name: "default", status: http.StatusInternalServerError, err: errors.New("new error ")},
+		{name: "default", status: http.StatusInternalServerError, err: errors.New("new error "), response: `{"status":"error","error":"something went wrong","code":"internal"}`},
 		{name: "internal server error", status: http.StatusInternalServerError, err: httperrors.ErrInternalServer},
 		{name: "invalid params", status: http.StatusBadRequest, err: httperrors.ErrInvalidTimezone},
 	}
@@ -67,6 +68,127 @@ func TestResponse_Error(t *testing.T) {
 
 			require.Equal(t, tc.status, resp.StatusCode)
 			assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+			if tc.response != "" {
+				body, _ := io.ReadAll(resp.Body)
+				assert.JSONEq(t, tc.response, string(body))
+			}
+		})
+	}
+}
+
+func TestResponse_Problem(t *testing.T) {
+	invalid := httperrors.Collect(
+		httperrors.Invalid("period", "1h", fmt.Errorf("%w:value must be positive", httperrors.ErrInvalidPeriod)),
+		httperrors.Invalid("t1", "20210714T204603Z", fmt.Errorf("%w:wrong", httperrors.ErrInvalidStartPoint)),
+	)
+
+	tt := []struct {
+		name     string
+		err      error
+		accept   string
+		status   int
+		response string
+	}{
+		{
+			name:     "legacy",
+			err:      invalid,
+			status:   http.StatusBadRequest,
+			response: `{"status":"error","error":"invalid period","code":"invalid_period"}`,
+		},
+		{
+			name:   "invalid parameters",
+			err:    invalid,
+			accept: "application/problem+json",
+			status: http.StatusBadRequest,
+			response: `{"type":"urn:ptask:problem:invalid-parameters","title":"invalid parameters","status":400,` +
+				`"detail":"period: invalid period:value must be positive; t1: invalid start point:wrong","errors":[` +
+				`{"param":"period","reason":"invalid period:value must be positive","example":"1h"},` +
+				`{"param":"t1","reason":"invalid start point:wrong","example":"20210714T204603Z"}]}`,
+		},
+		{
+			name:     "bad request",
+			err:      fmt.Errorf("%w:unknown operator", httperrors.ErrInvalidExpression),
+			accept:   "application/problem+json",
+			status:   http.StatusBadRequest,
+			response: `{"type":"urn:ptask:problem:invalid-expression","title":"invalid expression","status":400,"detail":"invalid expression:unknown operator"}`,
+		},
+		{
+			name:     "internal server error",
+			err:      httperrors.ErrInternalServer,
+			accept:   "application/problem+json",
+			status:   http.StatusInternalServerError,
+			response: `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"something went wrong"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set("Accept", tc.accept)
+
+			NegotiateProblems(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				Error(w, tc.err)
+			})).ServeHTTP(w, r)
+
+			resp := w.Result()
+			body, _ := io.ReadAll(resp.Body)
+
+			require.Equal(t, tc.status, resp.StatusCode)
+			assert.JSONEq(t, tc.response, string(body))
+
+			if tc.accept != "" {
+				assert.Equal(t, "application/problem+json", resp.Header.Get("Content-Type"))
+			}
+		})
+	}
+}
+
+// wrapper stands for the writers of the middlewares, e.g. the recorder of the metrics.
+type wrapper struct {
+	http.ResponseWriter
+}
+
+func (w *wrapper) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func TestResponse_ProblemMode(t *testing.T) {
+	// recoverPanic writes its error with the writer it was given, outside of Problems.
+	recoverPanic := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w = &wrapper{ResponseWriter: w}
+
+			defer func() {
+				if err := recover(); err != nil {
+					Error(w, httperrors.ErrRecoverPanic)
+				}
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	panicking := http.HandlerFunc(func(http.ResponseWriter, *http.Request) { panic("boom") })
+
+	tt := []struct {
+		name        string
+		handler     http.Handler
+		contentType string
+	}{
+		{name: "problems", handler: ProblemMode(recoverPanic(Problems(panicking))), contentType: constants.ProblemContentType},
+		{name: "envelope", handler: ProblemMode(recoverPanic(panicking)), contentType: "application/json"},
+		{name: "without problem mode", handler: recoverPanic(Problems(panicking)), contentType: "application/json"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			tc.handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			assert.Equal(t, http.StatusInternalServerError, w.Code)
+			assert.Equal(t, tc.contentType, w.Header().Get("Content-Type"))
 		})
 	}
 }
@@ -2,25 +2,110 @@ package utils
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"strconv"
 	"strings"
 	"time"
-	"unicode"
 
 	"github.com/KarolosLykos/ptask/internal/constants"
 	"github.com/KarolosLykos/ptask/internal/logger"
 	"github.com/KarolosLykos/ptask/internal/ptask/domain"
+	"github.com/KarolosLykos/ptask/internal/tracing"
+	"github.com/KarolosLykos/ptask/internal/tzdata"
 	"github.com/KarolosLykos/ptask/internal/utils/httperrors"
+	"github.com/KarolosLykos/ptask/pkg/schedule"
+)
+
+// examples of valid values, reported along with the invalid parameters of a request.
+const (
+	examplePeriod    = "1h"
+	exampleTimezone  = "Europe/Athens"
+	exampleTimestamp = "20210714T204603Z"
+	exampleBlackout  = "20210729T000000Z/20210730T000000Z"
+	exampleCount     = "5"
 )
 
 type ListQueryParams struct {
+	Period       *domain.Period
+	Timezone     *time.Location
+	T1           time.Time
+	T2           time.Time
+	Blackouts    []domain.Blackout
+	BlackoutSets []string
+	DisplayZones []*time.Location
+	// Lang is the language the period is described in by verbose responses.
+	Lang string
+	// Limit stops the schedule after as many listed occurrences, 0 lists every occurrence of the window.
+	Limit int
+}
+
+type DescribeParams struct {
 	Period   *domain.Period
+	Timezone *time.Location
+	Lang     string
+}
+
+type ParseParams struct {
+	Query    string
+	Timezone *time.Location
+	T1       time.Time
+	Count    int
+	Lang     string
+}
+
+type TransitionParams struct {
 	Timezone *time.Location
 	T1       time.Time
 	T2       time.Time
 }
 
+type ComposeParams struct {
+	Expr     *domain.Expression
+	Timezone *time.Location
+	T1       time.Time
+	T2       time.Time
+}
+
+type composeRequest struct {
+	Expr *expressionNode `json:"expr"`
+	TZ   string          `json:"tz"`
+	T1   string          `json:"t1"`
+	T2   string          `json:"t2"`
+}
+
+type expressionNode struct {
+	Op     string            `json:"op"`
+	Period string            `json:"period"`
+	Args   []*expressionNode `json:"args"`
+}
+
+type CollisionParams struct {
+	Schedules []NamedPeriod
+	Threshold int
+	Timezone  *time.Location
+	T1        time.Time
+	T2        time.Time
+}
+
+type NamedPeriod struct {
+	Name   string
+	Period *domain.Period
+}
+
+type collisionRequest struct {
+	Schedules []struct {
+		Name   string `json:"name"`
+		Period string `json:"period"`
+	} `json:"schedules"`
+	Threshold *int   `json:"threshold"`
+	TZ        string `json:"tz"`
+	T1        string `json:"t1"`
+	T2        string `json:"t2"`
+}
+
 func GetListQueryParams(
 	ctx context.Context,
 	logger logger.Logger,
@@ -29,53 +114,382 @@ func GetListQueryParams(
 	logger.Trace(ctx, "utils.GetListQueryParams")
 	defer logger.Trace(ctx, "utils.GetListQueryParams")
 
-	p, err := parsePeriod(ctx, logger, period)
-	if err != nil {
+	p, errP := parsePeriod(ctx, logger, period)
+	timeLoc, startPoint, endPoint, errW := parseWindow(tz, t1, t2)
+
+	if err := httperrors.Collect(httperrors.Invalid("period", examplePeriod, errP), errW); err != nil {
 		return nil, err
 	}
 
-	timeLoc, err := time.LoadLocation(tz)
+	return &ListQueryParams{
+		Period:   p,
+		Timezone: timeLoc,
+		T1:       startPoint,
+		T2:       endPoint,
+	}, nil
+}
+
+// GetDescribeQueryParams parses the period and timezone of a schedule description; lang defaults to English.
+func GetDescribeQueryParams(ctx context.Context, logger logger.Logger, period, tz, lang string) (*DescribeParams, error) {
+	logger.Trace(ctx, "utils.GetDescribeQueryParams")
+	defer logger.Trace(ctx, "utils.GetDescribeQueryParams")
+
+	p, errP := parsePeriod(ctx, logger, period)
+	timeLoc, errTz := parseTimezone(tz)
+
+	if err := httperrors.Collect(
+		httperrors.Invalid("period", examplePeriod, errP),
+		httperrors.Invalid("tz", exampleTimezone, errTz),
+	); err != nil {
+		return nil, err
+	}
+
+	if lang == "" {
+		lang = constants.LangEnglish
+	}
+
+	return &DescribeParams{Period: p, Timezone: timeLoc, Lang: strings.ToLower(lang)}, nil
+}
+
+// GetParseQueryParams parses the parameters of a natural-language schedule preview. The preview starts
+// at t1, or now when it is empty, and lists count occurrences; lang defaults to English.
+func GetParseQueryParams(ctx context.Context, logger logger.Logger, q, tz, t1, count, lang string) (*ParseParams, error) {
+	logger.Trace(ctx, "utils.GetParseQueryParams")
+	defer logger.Trace(ctx, "utils.GetParseQueryParams")
+
+	timeLoc, errTz := parseTimezone(tz)
+
+	var errT1, errCount error
+
+	startPoint := time.Now()
+	if t1 != "" {
+		if startPoint, errT1 = time.Parse(constants.TimestampLayout, t1); errT1 != nil {
+			errT1 = httperrors.Wrap(httperrors.ErrInvalidStartPoint, errT1)
+		}
+	}
+
+	n := constants.PreviewCount
+	if count != "" {
+		if n, errCount = strconv.Atoi(count); errCount != nil || n < 1 || n > constants.MaxPreviewCount {
+			errCount = httperrors.Wrapf(httperrors.ErrInvalidCount, "expected 1 to %d", constants.MaxPreviewCount)
+		}
+	}
+
+	if err := httperrors.Collect(
+		httperrors.Invalid("tz", exampleTimezone, errTz),
+		httperrors.Invalid("t1", exampleTimestamp, errT1),
+		httperrors.Invalid("count", exampleCount, errCount),
+	); err != nil {
+		return nil, err
+	}
+
+	if lang == "" {
+		lang = constants.LangEnglish
+	}
+
+	return &ParseParams{Query: q, Timezone: timeLoc, T1: startPoint.In(timeLoc), Count: n, Lang: strings.ToLower(lang)}, nil
+}
+
+// GetTransitionsQueryParams parses the timezone and window of a transitions request.
+func GetTransitionsQueryParams(ctx context.Context, logger logger.Logger, tz, t1, t2 string) (*TransitionParams, error) {
+	logger.Trace(ctx, "utils.GetTransitionsQueryParams")
+	defer logger.Trace(ctx, "utils.GetTransitionsQueryParams")
+
+	timeLoc, startPoint, endPoint, err := parseWindow(tz, t1, t2)
 	if err != nil {
-		return nil, fmt.Errorf("%w:%v", httperrors.ErrInvalidTimezone, err)
+		return nil, err
 	}
 
-	startPoint, err := time.Parse(constants.TimestampLayout, t1)
+	return &TransitionParams{Timezone: timeLoc, T1: startPoint, T2: endPoint}, nil
+}
+
+// GetComposeParams decodes a compose request body and parses its expression tree.
+func GetComposeParams(ctx context.Context, logger logger.Logger, body io.Reader) (*ComposeParams, error) {
+	logger.Trace(ctx, "utils.GetComposeParams")
+	defer logger.Trace(ctx, "utils.GetComposeParams")
+
+	req := &composeRequest{}
+	if err := json.NewDecoder(body).Decode(req); err != nil {
+		return nil, httperrors.Wrap(httperrors.ErrInvalidExpression, err)
+	}
+
+	expr, err := parseExpression(ctx, logger, req.Expr, 0)
 	if err != nil {
-		return nil, fmt.Errorf("%w:%v", httperrors.ErrInvalidStartPoint, err)
+		return nil, err
 	}
 
-	endPoint, err := time.Parse(constants.TimestampLayout, t2)
+	timeLoc, startPoint, endPoint, err := parseWindow(req.TZ, req.T1, req.T2)
 	if err != nil {
-		return nil, fmt.Errorf("%w:%v", httperrors.ErrInvalidEndPoint, err)
+		return nil, err
 	}
 
-	return &ListQueryParams{
-		Period:   p,
+	return &ComposeParams{
+		Expr:     expr,
 		Timezone: timeLoc,
-		T1:       startPoint.In(timeLoc),
-		T2:       endPoint.In(timeLoc),
+		T1:       startPoint,
+		T2:       endPoint,
 	}, nil
 }
 
+// GetCollisionParams decodes a collision analysis request body.
+func GetCollisionParams(ctx context.Context, logger logger.Logger, body io.Reader) (*CollisionParams, error) {
+	logger.Trace(ctx, "utils.GetCollisionParams")
+	defer logger.Trace(ctx, "utils.GetCollisionParams")
+
+	req := &collisionRequest{}
+	if err := json.NewDecoder(body).Decode(req); err != nil {
+		return nil, httperrors.Wrap(httperrors.ErrInvalidSchedules, err)
+	}
+
+	if len(req.Schedules) == 0 || len(req.Schedules) > constants.MaxSchedules {
+		return nil, httperrors.Wrapf(httperrors.ErrInvalidSchedules, "expected 1 to %d schedules", constants.MaxSchedules)
+	}
+
+	threshold := 1
+	if req.Threshold != nil {
+		if *req.Threshold < 0 {
+			return nil, httperrors.Wrapf(httperrors.ErrInvalidSchedules, "threshold must not be negative")
+		}
+
+		threshold = *req.Threshold
+	}
+
+	schedules := make([]NamedPeriod, 0, len(req.Schedules))
+	names := make(map[string]bool, len(req.Schedules))
+
+	for _, s := range req.Schedules {
+		if s.Name == "" || names[s.Name] {
+			return nil, httperrors.Wrapf(httperrors.ErrInvalidSchedules, "schedule names must be unique and not empty")
+		}
+
+		names[s.Name] = true
+
+		p, err := parsePeriod(ctx, logger, s.Period)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", s.Name, err)
+		}
+
+		schedules = append(schedules, NamedPeriod{Name: s.Name, Period: p})
+	}
+
+	timeLoc, startPoint, endPoint, err := parseWindow(req.TZ, req.T1, req.T2)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CollisionParams{
+		Schedules: schedules,
+		Threshold: threshold,
+		Timezone:  timeLoc,
+		T1:        startPoint,
+		T2:        endPoint,
+	}, nil
+}
+
+func parseExpression(ctx context.Context, logger logger.Logger, node *expressionNode, depth int) (*domain.Expression, error) {
+	if node == nil {
+		return nil, httperrors.Wrapf(httperrors.ErrInvalidExpression, "missing expression")
+	}
+
+	if depth > constants.MaxExprDepth {
+		return nil, httperrors.Wrapf(httperrors.ErrInvalidExpression, "expression deeper than %d", constants.MaxExprDepth)
+	}
+
+	if node.Op == "" {
+		if len(node.Args) > 0 {
+			return nil, httperrors.Wrapf(httperrors.ErrInvalidExpression, "a period can not have args")
+		}
+
+		p, err := parsePeriod(ctx, logger, node.Period)
+		if err != nil {
+			return nil, err
+		}
+
+		return &domain.Expression{Period: p}, nil
+	}
+
+	switch node.Op {
+	case constants.Union, constants.Intersect, constants.Except:
+	default:
+		return nil, httperrors.Wrapf(httperrors.ErrInvalidExpression, "unknown operator %q", node.Op)
+	}
+
+	if node.Period != "" || len(node.Args) == 0 {
+		return nil, httperrors.Wrapf(httperrors.ErrInvalidExpression, "operator %q needs args and no period", node.Op)
+	}
+
+	expr := &domain.Expression{Op: node.Op, Args: make([]*domain.Expression, 0, len(node.Args))}
+
+	for _, arg := range node.Args {
+		a, err := parseExpression(ctx, logger, arg, depth+1)
+		if err != nil {
+			return nil, err
+		}
+
+		expr.Args = append(expr.Args, a)
+	}
+
+	return expr, nil
+}
+
+// parseWindow parses the timezone and the points of a window, reporting every invalid one.
+func parseWindow(tz, t1, t2 string) (*time.Location, time.Time, time.Time, error) {
+	timeLoc, errTz := parseTimezone(tz)
+
+	startPoint, errT1 := time.Parse(constants.TimestampLayout, t1)
+	if errT1 != nil {
+		errT1 = httperrors.Wrap(httperrors.ErrInvalidStartPoint, errT1)
+	}
+
+	endPoint, errT2 := time.Parse(constants.TimestampLayout, t2)
+	if errT2 != nil {
+		errT2 = httperrors.Wrap(httperrors.ErrInvalidEndPoint, errT2)
+	}
+
+	if err := httperrors.Collect(
+		httperrors.Invalid("tz", exampleTimezone, errTz),
+		httperrors.Invalid("t1", exampleTimestamp, errT1),
+		httperrors.Invalid("t2", exampleTimestamp, errT2),
+	); err != nil {
+		return nil, time.Time{}, time.Time{}, err
+	}
+
+	return timeLoc, startPoint.In(timeLoc), endPoint.In(timeLoc), nil
+}
+
+// parseTimezone loads tz from the embedded, versioned tz database, or parses it as a fixed offset
+// or a POSIX TZ string.
+func parseTimezone(tz string) (*time.Location, error) {
+	timeLoc, err := tzdata.Parse(tz)
+	if err != nil {
+		return nil, httperrors.Wrap(httperrors.ErrInvalidTimezone, err)
+	}
+
+	return timeLoc, nil
+}
+
 func parsePeriod(ctx context.Context, logger logger.Logger, period string) (*domain.Period, error) {
 	logger.Trace(ctx, "utils.parsePeriod")
 	defer logger.Trace(ctx, "utils.parsePeriod")
 
-	n := strings.IndexFunc(period, unicode.IsLetter)
-	if n == -1 {
-		return nil, httperrors.ErrInvalidPeriod
+	_, span := tracing.Start(ctx, "utils.parsePeriod", tracing.PeriodKey.String(period))
+
+	p, err := schedule.ParsePeriod(period)
+	err = httperrors.WrapPeriod(err)
+	tracing.End(span, err)
+
+	return p, err
+}
+
+// GetBlackouts parses blackout intervals given as "t1/t2" using the timestamp layout.
+func GetBlackouts(ctx context.Context, logger logger.Logger, blackouts []string) ([]domain.Blackout, error) {
+	logger.Trace(ctx, "utils.GetBlackouts")
+	defer logger.Trace(ctx, "utils.GetBlackouts")
+
+	list := make([]domain.Blackout, 0, len(blackouts))
+	errs := make([]error, 0)
+
+	for _, blackout := range blackouts {
+		start, end, found := strings.Cut(blackout, "/")
+		if !found {
+			errs = append(errs, httperrors.Invalid("blackout", exampleBlackout, httperrors.Wrapf(httperrors.ErrInvalidBlackout, "%s", blackout)))
+			continue
+		}
+
+		b, err := parseBlackout(start, end)
+		if err != nil {
+			errs = append(errs, httperrors.Invalid("blackout", exampleBlackout, err))
+			continue
+		}
+
+		list = append(list, b)
+	}
+
+	if err := httperrors.Collect(errs...); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// GetDisplayZones parses the timezones occurrences are rendered in, besides the one they are computed in.
+func GetDisplayZones(ctx context.Context, logger logger.Logger, zones []string) ([]*time.Location, error) {
+	logger.Trace(ctx, "utils.GetDisplayZones")
+	defer logger.Trace(ctx, "utils.GetDisplayZones")
+
+	if len(zones) > constants.MaxDisplayZones {
+		err := httperrors.Wrapf(httperrors.ErrInvalidTimezone, "at most %d display zones", constants.MaxDisplayZones)
+		return nil, httperrors.Invalid("display_tz", exampleTimezone, err)
+	}
+
+	locations := make([]*time.Location, 0, len(zones))
+	errs := make([]error, 0)
+
+	for _, zone := range zones {
+		loc, err := parseTimezone(zone)
+		if err != nil {
+			errs = append(errs, httperrors.Invalid("display_tz", exampleTimezone, err))
+			continue
+		}
+
+		locations = append(locations, loc)
+	}
+
+	if err := httperrors.Collect(errs...); err != nil {
+		return nil, err
 	}
 
-	v, err := strconv.Atoi(period[:n])
+	return locations, nil
+}
+
+// ReadBlackoutSets reads named blackout sets from a JSON file of the form
+// {"name": [{"start": "20060102T150405Z", "end": "20060102T150405Z"}]}.
+func ReadBlackoutSets(path string) (domain.BlackoutSets, error) {
+	f, err := os.Open(path)
 	if err != nil {
-		return nil, fmt.Errorf("%w:%v", httperrors.ErrInvalidPeriod, err)
+		return nil, err
 	}
+	defer f.Close()
 
-	p := strings.ToLower(period[n:])
-	switch p {
-	case constants.Year, constants.Month, constants.Day, constants.Hour:
-		return &domain.Period{Value: v, PeriodType: p}, nil
-	default:
-		return nil, fmt.Errorf("%w:%v", httperrors.ErrInvalidPeriod, err)
+	raw := map[string][]struct {
+		Start string `json:"start"`
+		End   string `json:"end"`
+	}{}
+
+	if err = json.NewDecoder(f).Decode(&raw); err != nil {
+		return nil, httperrors.Wrap(httperrors.ErrInvalidBlackout, err)
+	}
+
+	sets := make(domain.BlackoutSets, len(raw))
+
+	for name, intervals := range raw {
+		for _, interval := range intervals {
+			b, err := parseBlackout(interval.Start, interval.End)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+
+			sets[name] = append(sets[name], b)
+		}
+	}
+
+	return sets, nil
+}
+
+func parseBlackout(start, end string) (domain.Blackout, error) {
+	startPoint, err := time.Parse(constants.TimestampLayout, start)
+	if err != nil {
+		return domain.Blackout{}, httperrors.Wrap(httperrors.ErrInvalidBlackout, err)
+	}
+
+	endPoint, err := time.Parse(constants.TimestampLayout, end)
+	if err != nil {
+		return domain.Blackout{}, httperrors.Wrap(httperrors.ErrInvalidBlackout, err)
 	}
+
+	if !endPoint.After(startPoint) {
+		return domain.Blackout{}, httperrors.Wrapf(httperrors.ErrInvalidBlackout, "end must be after start")
+	}
+
+	return domain.Blackout{Start: startPoint, End: endPoint}, nil
 }
@@ -4,16 +4,20 @@ import (
 	"context"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/KarolosLykos/ptask/internal/constants"
 	"github.com/KarolosLykos/ptask/internal/logger"
 	"github.com/KarolosLykos/ptask/internal/logger/log"
 	"github.com/KarolosLykos/ptask/internal/ptask/domain"
+	"github.com/KarolosLykos/ptask/internal/tzdata"
 	"github.com/KarolosLykos/ptask/internal/utils/httperrors"
 )
 
@@ -39,6 +43,9 @@ func TestGetListQueryParams(t *testing.T) {
 		{
 			name: "invalid end point", period: "1h", tz: "Europe/Athens", t1: "20060102T150405Z", t2: "wrong", err: httperrors.ErrInvalidEndPoint,
 		},
+		{
+			name: "every invalid param", period: "a", tz: "WrontTZ", t1: "wrong", t2: "wrong", err: httperrors.ErrInvalidEndPoint,
+		},
 		{
 			name:   "ok",
 			period: "1h",
@@ -67,6 +74,20 @@ func TestGetListQueryParams(t *testing.T) {
 	}
 }
 
+func TestGetListQueryParams_collectsErrors(t *testing.T) {
+	_, err := GetListQueryParams(context.TODO(), getLogger(), "a", "Europe/Athens", "wrong", "wrong")
+
+	var v *httperrors.ValidationError
+	require.ErrorAs(t, err, &v)
+	require.Len(t, v.Fields, 3)
+
+	assert.Equal(t, []string{"period", "t1", "t2"}, []string{v.Fields[0].Param, v.Fields[1].Param, v.Fields[2].Param})
+	assert.Equal(t, "20210714T204603Z", v.Fields[1].Example)
+
+	// the first invalid param keeps answering with its message.
+	assert.Equal(t, "invalid period", httperrors.Message(err))
+}
+
 func TestParsePeriod(t *testing.T) {
 	l := getLogger()
 	ctx := context.TODO()
@@ -86,6 +107,9 @@ func TestParsePeriod(t *testing.T) {
 		{
 			name: "invalid type", period: "10w", parsed: nil, err: httperrors.ErrInvalidPeriod,
 		},
+		{
+			name: "zero value", period: "0d", parsed: nil, err: httperrors.ErrInvalidPeriod,
+		},
 		{
 			name: "1y", period: "1y", parsed: &domain.Period{Value: 1, PeriodType: constants.Year},
 		},
@@ -113,6 +137,280 @@ func TestParsePeriod(t *testing.T) {
 	}
 }
 
+func TestParseTimezone(t *testing.T) {
+	tt := []struct {
+		tz     string
+		offset int
+		err    error
+	}{
+		{tz: "Europe/Athens", offset: 3 * 3600},
+		{tz: "+03:00", offset: 3 * 3600},
+		{tz: "UTC-5", offset: -5 * 3600},
+		{tz: "EST5EDT,M3.2.0,M11.1.0", offset: -4 * 3600},
+		{tz: "Europe/Nowhere", err: tzdata.ErrInvalidName},
+		{tz: "+3:75", err: tzdata.ErrInvalidOffset},
+		{tz: "EST5EDT,M3.2.0", err: tzdata.ErrInvalidPOSIX},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.tz, func(t *testing.T) {
+			loc, err := parseTimezone(tc.tz)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, httperrors.ErrInvalidTimezone)
+				assert.ErrorContains(t, err, tc.err.Error())
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			_, offset := time.Date(2021, 7, 14, 0, 0, 0, 0, loc).Zone()
+			assert.Equal(t, tc.offset, offset)
+		})
+	}
+}
+
+func TestGetDisplayZones(t *testing.T) {
+	l := getLogger()
+	ctx := context.TODO()
+
+	zones, err := GetDisplayZones(ctx, l, []string{"America/New_York", "Asia/Tokyo", "+05:30"})
+	require.NoError(t, err)
+	require.Len(t, zones, 3)
+	assert.Equal(t, "America/New_York", zones[0].String())
+	assert.Equal(t, "Asia/Tokyo", zones[1].String())
+	assert.Equal(t, "UTC+05:30", zones[2].String())
+
+	zones, err = GetDisplayZones(ctx, l, nil)
+	require.NoError(t, err)
+	assert.Empty(t, zones)
+
+	_, err = GetDisplayZones(ctx, l, []string{"Asia/Nowhere"})
+	assert.ErrorIs(t, err, httperrors.ErrInvalidTimezone)
+
+	_, err = GetDisplayZones(ctx, l, make([]string, constants.MaxDisplayZones+1))
+	assert.ErrorIs(t, err, httperrors.ErrInvalidTimezone)
+}
+
+func TestGetBlackouts(t *testing.T) {
+	l := getLogger()
+	ctx := context.TODO()
+
+	tt := []struct {
+		name      string
+		blackouts []string
+		parsed    []domain.Blackout
+		err       error
+	}{
+		{
+			name: "empty", blackouts: nil, parsed: []domain.Blackout{},
+		},
+		{
+			name: "missing separator", blackouts: []string{"20210729T000000Z"}, err: httperrors.ErrInvalidBlackout,
+		},
+		{
+			name: "invalid start", blackouts: []string{"wrong/20210730T000000Z"}, err: httperrors.ErrInvalidBlackout,
+		},
+		{
+			name: "invalid end", blackouts: []string{"20210729T000000Z/wrong"}, err: httperrors.ErrInvalidBlackout,
+		},
+		{
+			name: "end before start", blackouts: []string{"20210730T000000Z/20210729T000000Z"}, err: httperrors.ErrInvalidBlackout,
+		},
+		{
+			name:      "ok",
+			blackouts: []string{"20210729T000000Z/20210730T000000Z"},
+			parsed: []domain.Blackout{
+				{Start: time.Date(2021, 7, 29, 0, 0, 0, 0, time.UTC), End: time.Date(2021, 7, 30, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := GetBlackouts(ctx, l, tc.blackouts)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+			} else {
+				assert.NoError(t, err)
+				assert.EqualValues(t, tc.parsed, b)
+			}
+		})
+	}
+}
+
+func TestReadBlackoutSets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blackouts.json")
+	content := `{"freeze": [{"start": "20211220T000000Z", "end": "20220103T000000Z"}]}`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	sets, err := ReadBlackoutSets(path)
+	require.NoError(t, err)
+	assert.EqualValues(t, domain.BlackoutSets{
+		"freeze": {{Start: time.Date(2021, 12, 20, 0, 0, 0, 0, time.UTC), End: time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC)}},
+	}, sets)
+
+	invalid := filepath.Join(t.TempDir(), "invalid.json")
+	require.NoError(t, os.WriteFile(invalid, []byte(`{"freeze": [{"start": "wrong"}]}`), 0o600))
+
+	_, err = ReadBlackoutSets(invalid)
+	assert.ErrorIs(t, err, httperrors.ErrInvalidBlackout)
+}
+
+func TestGetDescribeQueryParams(t *testing.T) {
+	l := getLogger()
+	ctx := context.TODO()
+
+	_, err := GetDescribeQueryParams(ctx, l, "1w", "", "")
+	assert.ErrorIs(t, err, httperrors.ErrInvalidPeriod)
+
+	_, err = GetDescribeQueryParams(ctx, l, "1mo", "Wrong", "")
+	assert.ErrorIs(t, err, httperrors.ErrInvalidTimezone)
+
+	p, err := GetDescribeQueryParams(ctx, l, "1mo", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, &DescribeParams{Period: &domain.Period{Value: 1, PeriodType: constants.Month}, Timezone: time.UTC, Lang: constants.LangEnglish}, p)
+
+	p, err = GetDescribeQueryParams(ctx, l, "1mo", "", "EL")
+	require.NoError(t, err)
+	assert.Equal(t, constants.LangGreek, p.Lang)
+}
+
+func TestGetParseQueryParams(t *testing.T) {
+	l := getLogger()
+	ctx := context.TODO()
+
+	_, err := GetParseQueryParams(ctx, l, "daily", "Wrong", "", "", "")
+	assert.ErrorIs(t, err, httperrors.ErrInvalidTimezone)
+
+	_, err = GetParseQueryParams(ctx, l, "daily", "", "wrong", "", "")
+	assert.ErrorIs(t, err, httperrors.ErrInvalidStartPoint)
+
+	_, err = GetParseQueryParams(ctx, l, "daily", "", "", "0", "")
+	assert.ErrorIs(t, err, httperrors.ErrInvalidCount)
+
+	_, err = GetParseQueryParams(ctx, l, "daily", "", "", "101", "")
+	assert.ErrorIs(t, err, httperrors.ErrInvalidCount)
+
+	p, err := GetParseQueryParams(ctx, l, "daily", "", "20060102T150405Z", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, &ParseParams{Query: "daily", Timezone: time.UTC, T1: time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC), Count: constants.PreviewCount, Lang: constants.LangEnglish}, p)
+
+	p, err = GetParseQueryParams(ctx, l, "daily", "", "", "10", "EL")
+	require.NoError(t, err)
+	assert.Equal(t, 10, p.Count)
+	assert.Equal(t, constants.LangGreek, p.Lang)
+	assert.WithinDuration(t, time.Now(), p.T1, time.Minute)
+}
+
+func TestGetTransitionsQueryParams(t *testing.T) {
+	l := getLogger()
+	ctx := context.TODO()
+
+	_, err := GetTransitionsQueryParams(ctx, l, "Wrong", "20210101T000000Z", "20220101T000000Z")
+	assert.ErrorIs(t, err, httperrors.ErrInvalidTimezone)
+
+	_, err = GetTransitionsQueryParams(ctx, l, "Europe/Athens", "20210101T000000Z", "wrong")
+	assert.ErrorIs(t, err, httperrors.ErrInvalidEndPoint)
+
+	p, err := GetTransitionsQueryParams(ctx, l, "UTC", "20210101T000000Z", "20220101T000000Z")
+	require.NoError(t, err)
+	assert.Equal(t, &TransitionParams{
+		Timezone: time.UTC,
+		T1:       time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		T2:       time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+	}, p)
+}
+
+func TestGetComposeParams(t *testing.T) {
+	l := getLogger()
+	ctx := context.TODO()
+
+	window := `"tz": "Europe/Athens", "t1": "20210714T204603Z", "t2": "20210715T123456Z"`
+
+	tt := []struct {
+		name string
+		body string
+		expr *domain.Expression
+		err  error
+	}{
+		{name: "invalid json", body: `{`, err: httperrors.ErrInvalidExpression},
+		{name: "missing expression", body: `{` + window + `}`, err: httperrors.ErrInvalidExpression},
+		{name: "unknown operator", body: `{"expr": {"op": "xor", "args": [{"period": "1h"}]}, ` + window + `}`, err: httperrors.ErrInvalidExpression},
+		{name: "operator without args", body: `{"expr": {"op": "union"}, ` + window + `}`, err: httperrors.ErrInvalidExpression},
+		{name: "invalid period", body: `{"expr": {"op": "union", "args": [{"period": "1w"}]}, ` + window + `}`, err: httperrors.ErrInvalidPeriod},
+		{name: "invalid timezone", body: `{"expr": {"period": "1h"}, "tz": "Wrong"}`, err: httperrors.ErrInvalidTimezone},
+		{
+			name: "ok",
+			body: `{"expr": {"op": "intersect", "args": [{"period": "1h"}, {"period": "6h"}]}, ` + window + `}`,
+			expr: &domain.Expression{Op: constants.Intersect, Args: []*domain.Expression{
+				{Period: &domain.Period{Value: 1, PeriodType: constants.Hour}},
+				{Period: &domain.Period{Value: 6, PeriodType: constants.Hour}},
+			}},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := GetComposeParams(ctx, l, strings.NewReader(tc.body))
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+				assert.EqualValues(t, tc.expr, p.Expr)
+				assert.Equal(t, "Europe/Athens", p.Timezone.String())
+			}
+		})
+	}
+}
+
+func TestGetCollisionParams(t *testing.T) {
+	l := getLogger()
+	ctx := context.TODO()
+
+	window := `"tz": "Europe/Athens", "t1": "20210714T204603Z", "t2": "20210715T123456Z"`
+
+	tt := []struct {
+		name      string
+		body      string
+		schedules []NamedPeriod
+		threshold int
+		err       error
+	}{
+		{name: "invalid json", body: `[`, err: httperrors.ErrInvalidSchedules},
+		{name: "no schedules", body: `{` + window + `}`, err: httperrors.ErrInvalidSchedules},
+		{name: "duplicate names", body: `{"schedules": [{"name": "a", "period": "1h"}, {"name": "a", "period": "1d"}], ` + window + `}`, err: httperrors.ErrInvalidSchedules},
+		{name: "negative threshold", body: `{"threshold": -1, "schedules": [{"name": "a", "period": "1h"}], ` + window + `}`, err: httperrors.ErrInvalidSchedules},
+		{name: "invalid period", body: `{"schedules": [{"name": "a", "period": "1w"}], ` + window + `}`, err: httperrors.ErrInvalidPeriod},
+		{name: "invalid end point", body: `{"schedules": [{"name": "a", "period": "1h"}], "t1": "20210714T204603Z"}`, err: httperrors.ErrInvalidEndPoint},
+		{
+			name:      "default threshold",
+			body:      `{"schedules": [{"name": "a", "period": "1h"}, {"name": "b", "period": "6h"}], ` + window + `}`,
+			schedules: []NamedPeriod{{Name: "a", Period: &domain.Period{Value: 1, PeriodType: constants.Hour}}, {Name: "b", Period: &domain.Period{Value: 6, PeriodType: constants.Hour}}},
+			threshold: 1,
+		},
+		{
+			name:      "threshold",
+			body:      `{"threshold": 3, "schedules": [{"name": "a", "period": "1d"}], ` + window + `}`,
+			schedules: []NamedPeriod{{Name: "a", Period: &domain.Period{Value: 1, PeriodType: constants.Day}}},
+			threshold: 3,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := GetCollisionParams(ctx, l, strings.NewReader(tc.body))
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+				assert.EqualValues(t, tc.schedules, p.Schedules)
+				assert.Equal(t, tc.threshold, p.Threshold)
+			}
+		})
+	}
+}
+
 func getLogger() logger.Logger {
 	l := &logrus.Logger{
 		Out:          io.Discard,
@@ -0,0 +1,244 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: ptask/v1/ptask.proto
+
+package ptaskpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	PeriodicTaskService_List_FullMethodName   = "/ptask.v1.PeriodicTaskService/List"
+	PeriodicTaskService_Stream_FullMethodName = "/ptask.v1.PeriodicTaskService/Stream"
+	PeriodicTaskService_Match_FullMethodName  = "/ptask.v1.PeriodicTaskService/Match"
+)
+
+// PeriodicTaskServiceClient is the client API for PeriodicTaskService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PeriodicTaskServiceClient interface {
+	// List returns the timestamps of a periodic task in [t1, t2).
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
+	// Stream sends the timestamps of a periodic task in [t1, t2) one at a time.
+	Stream(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (PeriodicTaskService_StreamClient, error)
+	// Match sends, for every timestamp of the request, whether it is an invocation of the periodic task.
+	Match(ctx context.Context, in *MatchRequest, opts ...grpc.CallOption) (PeriodicTaskService_MatchClient, error)
+}
+
+type periodicTaskServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPeriodicTaskServiceClient(cc grpc.ClientConnInterface) PeriodicTaskServiceClient {
+	return &periodicTaskServiceClient{cc}
+}
+
+func (c *periodicTaskServiceClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error) {
+	out := new(ListResponse)
+	err := c.cc.Invoke(ctx, PeriodicTaskService_List_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *periodicTaskServiceClient) Stream(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (PeriodicTaskService_StreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PeriodicTaskService_ServiceDesc.Streams[0], PeriodicTaskService_Stream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &periodicTaskServiceStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PeriodicTaskService_StreamClient interface {
+	Recv() (*Occurrence, error)
+	grpc.ClientStream
+}
+
+type periodicTaskServiceStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *periodicTaskServiceStreamClient) Recv() (*Occurrence, error) {
+	m := new(Occurrence)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *periodicTaskServiceClient) Match(ctx context.Context, in *MatchRequest, opts ...grpc.CallOption) (PeriodicTaskService_MatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PeriodicTaskService_ServiceDesc.Streams[1], PeriodicTaskService_Match_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &periodicTaskServiceMatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PeriodicTaskService_MatchClient interface {
+	Recv() (*MatchResult, error)
+	grpc.ClientStream
+}
+
+type periodicTaskServiceMatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *periodicTaskServiceMatchClient) Recv() (*MatchResult, error) {
+	m := new(MatchResult)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// PeriodicTaskServiceServer is the server API for PeriodicTaskService service.
+// All implementations must embed UnimplementedPeriodicTaskServiceServer
+// for forward compatibility
+type PeriodicTaskServiceServer interface {
+	// List returns the timestamps of a periodic task in [t1, t2).
+	List(context.Context, *ListRequest) (*ListResponse, error)
+	// Stream sends the timestamps of a periodic task in [t1, t2) one at a time.
+	Stream(*ListRequest, PeriodicTaskService_StreamServer) error
+	// Match sends, for every timestamp of the request, whether it is an invocation of the periodic task.
+	Match(*MatchRequest, PeriodicTaskService_MatchServer) error
+	mustEmbedUnimplementedPeriodicTaskServiceServer()
+}
+
+// UnimplementedPeriodicTaskServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedPeriodicTaskServiceServer struct {
+}
+
+func (UnimplementedPeriodicTaskServiceServer) List(context.Context, *ListRequest) (*ListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedPeriodicTaskServiceServer) Stream(*ListRequest, PeriodicTaskService_StreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method Stream not implemented")
+}
+func (UnimplementedPeriodicTaskServiceServer) Match(*MatchRequest, PeriodicTaskService_MatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Match not implemented")
+}
+func (UnimplementedPeriodicTaskServiceServer) mustEmbedUnimplementedPeriodicTaskServiceServer() {}
+
+// UnsafePeriodicTaskServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PeriodicTaskServiceServer will
+// result in compilation errors.
+type UnsafePeriodicTaskServiceServer interface {
+	mustEmbedUnimplementedPeriodicTaskServiceServer()
+}
+
+func RegisterPeriodicTaskServiceServer(s grpc.ServiceRegistrar, srv PeriodicTaskServiceServer) {
+	s.RegisterService(&PeriodicTaskService_ServiceDesc, srv)
+}
+
+func _PeriodicTaskService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PeriodicTaskServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PeriodicTaskService_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeriodicTaskServiceServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PeriodicTaskService_Stream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PeriodicTaskServiceServer).Stream(m, &periodicTaskServiceStreamServer{stream})
+}
+
+type PeriodicTaskService_StreamServer interface {
+	Send(*Occurrence) error
+	grpc.ServerStream
+}
+
+type periodicTaskServiceStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *periodicTaskServiceStreamServer) Send(m *Occurrence) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _PeriodicTaskService_Match_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(MatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PeriodicTaskServiceServer).Match(m, &periodicTaskServiceMatchServer{stream})
+}
+
+type PeriodicTaskService_MatchServer interface {
+	Send(*MatchResult) error
+	grpc.ServerStream
+}
+
+type periodicTaskServiceMatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *periodicTaskServiceMatchServer) Send(m *MatchResult) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// PeriodicTaskService_ServiceDesc is the grpc.ServiceDesc for PeriodicTaskService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PeriodicTaskService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ptask.v1.PeriodicTaskService",
+	HandlerType: (*PeriodicTaskServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "List",
+			Handler:    _PeriodicTaskService_List_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Stream",
+			Handler:       _PeriodicTaskService_Stream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Match",
+			Handler:       _PeriodicTaskService_Match_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "ptask/v1/ptask.proto",
+}
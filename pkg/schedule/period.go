@@ -0,0 +1,92 @@
+// Package schedule computes the invocations of periodic tasks in-process.
+//
+//	period, err := schedule.ParsePeriod("1h")
+//	...
+//	task, err := schedule.New(period, loc, t1)
+//	...
+//	points, err := task.All(t1, t2)
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// The supported period types.
+const (
+	Year  = "y"
+	Month = "mo"
+	Day   = "d"
+	Hour  = "h"
+)
+
+// ErrInvalidPeriod is returned for malformed periods and unsupported period types.
+var ErrInvalidPeriod = errors.New("invalid period")
+
+// maxValues bounds the value of each period type so a period, at the longest its unit lasts, fits in a
+// time.Duration, i.e. about 292 years. Stepping by a longer period would overflow.
+var maxValues = map[string]int{
+	Year:  int(math.MaxInt64 / int64(366*24*time.Hour)),
+	Month: int(math.MaxInt64 / int64(31*24*time.Hour)),
+	Day:   int(math.MaxInt64 / int64(25*time.Hour)),
+	Hour:  int(math.MaxInt64 / int64(time.Hour)),
+}
+
+// Period is a positive value of a period type, e.g. 2 days.
+type Period struct {
+	Value      int
+	PeriodType string
+}
+
+// ParsePeriod parses a period such as "1h", "2d", "1mo" or "1y". The period type is case-insensitive.
+func ParsePeriod(period string) (*Period, error) {
+	n := strings.IndexFunc(period, unicode.IsLetter)
+	if n == -1 {
+		return nil, ErrInvalidPeriod
+	}
+
+	v, err := strconv.Atoi(period[:n])
+	if err != nil {
+		return nil, fmt.Errorf("%w:%v", ErrInvalidPeriod, err)
+	}
+
+	p := &Period{Value: v, PeriodType: strings.ToLower(period[n:])}
+	if err = p.validate(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// MaxValue returns the largest value a period of periodType can have, 0 for unsupported period types.
+func MaxValue(periodType string) int {
+	return maxValues[periodType]
+}
+
+// String formats the period the way ParsePeriod accepts it.
+func (p *Period) String() string {
+	return fmt.Sprintf("%d%s", p.Value, p.PeriodType)
+}
+
+func (p *Period) validate() error {
+	switch p.PeriodType {
+	case Year, Month, Day, Hour:
+	default:
+		return fmt.Errorf("%w:unknown period type %q", ErrInvalidPeriod, p.PeriodType)
+	}
+
+	if p.Value <= 0 {
+		return fmt.Errorf("%w:value must be positive", ErrInvalidPeriod)
+	}
+
+	if max := maxValues[p.PeriodType]; p.Value > max {
+		return fmt.Errorf("%w:value must be at most %d%s", ErrInvalidPeriod, max, p.PeriodType)
+	}
+
+	return nil
+}
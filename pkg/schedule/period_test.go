@@ -0,0 +1,44 @@
+package schedule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePeriod(t *testing.T) {
+	tt := []struct {
+		name   string
+		period string
+		parsed *Period
+		err    error
+	}{
+		{name: "empty period", period: "", err: ErrInvalidPeriod},
+		{name: "missing type", period: "5", err: ErrInvalidPeriod},
+		{name: "missing value", period: "d", err: ErrInvalidPeriod},
+		{name: "invalid type", period: "10w", err: ErrInvalidPeriod},
+		{name: "zero value", period: "0d", err: ErrInvalidPeriod},
+		{name: "negative value", period: "-1h", err: ErrInvalidPeriod},
+		{name: "1y", period: "1y", parsed: &Period{Value: 1, PeriodType: Year}},
+		{name: "1Mo", period: "1Mo", parsed: &Period{Value: 1, PeriodType: Month}},
+		{name: "2D", period: "2D", parsed: &Period{Value: 2, PeriodType: Day}},
+		{name: "1h", period: "1h", parsed: &Period{Value: 1, PeriodType: Hour}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := ParsePeriod(tc.period)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				assert.Nil(t, p)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.parsed, p)
+			assert.Equal(t, tc.parsed.String(), p.String())
+		})
+	}
+}
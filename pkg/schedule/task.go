@@ -0,0 +1,113 @@
+package schedule
+
+import (
+	"time"
+)
+
+// PeriodicTask is a task that runs every Period in Timezone, starting at InvocationPoint.
+type PeriodicTask struct {
+	Period          *Period
+	InvocationPoint time.Time
+	Timezone        *time.Location
+}
+
+// New returns the periodic task whose first invocation is the start of the unit of period that follows
+// startPoint in timezone, e.g. the next midnight for days. A nil timezone keeps the location of startPoint.
+func New(period *Period, timezone *time.Location, startPoint time.Time) (*PeriodicTask, error) {
+	if err := period.validate(); err != nil {
+		return nil, err
+	}
+
+	if timezone != nil {
+		startPoint = startPoint.In(timezone)
+	}
+
+	invocationPoint, err := InvocationPoint(period, startPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PeriodicTask{Period: period, InvocationPoint: invocationPoint, Timezone: timezone}, nil
+}
+
+// InvocationPoint returns the start of the unit of period that follows startPoint, in its location.
+func InvocationPoint(period *Period, startPoint time.Time) (time.Time, error) {
+	switch period.PeriodType {
+	case Year:
+		return time.Date(startPoint.Year()+1, 1, 1, 0, 0, 0, 0, startPoint.Location()), nil
+	case Month:
+		return time.Date(startPoint.Year(), startPoint.Month()+1, 1, 0, 0, 0, 0, startPoint.Location()), nil
+	case Day:
+		return time.Date(startPoint.Year(), startPoint.Month(), startPoint.Day()+1, 0, 0, 0, 0, startPoint.Location()), nil
+	case Hour:
+		return time.Date(
+			startPoint.Year(),
+			startPoint.Month(),
+			startPoint.Day(),
+			startPoint.Hour()+1,
+			0,
+			0,
+			0,
+			startPoint.Location(),
+		), nil
+	default:
+		return time.Time{}, ErrInvalidPeriod
+	}
+}
+
+// Next returns the invocation that follows the given point.
+func (p *PeriodicTask) Next(point time.Time) (time.Time, error) {
+	return p.step(point, p.Period.Value)
+}
+
+// Prev returns the invocation that precedes the given point.
+func (p *PeriodicTask) Prev(point time.Time) (time.Time, error) {
+	return p.step(point, -p.Period.Value)
+}
+
+func (p *PeriodicTask) step(point time.Time, value int) (time.Time, error) {
+	switch p.Period.PeriodType {
+	case Year:
+		return point.AddDate(value, 0, 0), nil
+	case Month:
+		return point.AddDate(0, value, 0), nil
+	case Day:
+		return point.AddDate(0, 0, value), nil
+	case Hour:
+		return point.Add(time.Duration(value) * time.Hour), nil
+	default:
+		return time.Time{}, ErrInvalidPeriod
+	}
+}
+
+// All returns the invocations in [t1, t2), in ascending order.
+func (p *PeriodicTask) All(t1, t2 time.Time) ([]time.Time, error) {
+	points := []time.Time{}
+
+	err := p.Each(t1, t2, func(point time.Time) bool {
+		points = append(points, point)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return points, nil
+}
+
+// Each calls fn with the invocations in [t1, t2), in ascending order, until fn returns false.
+func (p *PeriodicTask) Each(t1, t2 time.Time, fn func(point time.Time) bool) error {
+	var err error
+
+	for point := p.InvocationPoint; point.Before(t2); {
+		if !point.Before(t1) && !fn(point) {
+			return nil
+		}
+
+		if point, err = p.Next(point); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	athens, err := time.LoadLocation("Europe/Athens")
+	require.NoError(t, err)
+
+	start := time.Date(2021, 7, 14, 20, 46, 3, 0, time.UTC)
+
+	tt := []struct {
+		name            string
+		period          *Period
+		invocationPoint time.Time
+		err             error
+	}{
+		{name: "invalid period", period: &Period{Value: 1, PeriodType: "w"}, err: ErrInvalidPeriod},
+		{name: "zero value", period: &Period{Value: 0, PeriodType: Day}, err: ErrInvalidPeriod},
+		{name: "year", period: &Period{Value: 1, PeriodType: Year}, invocationPoint: time.Date(2022, 1, 1, 0, 0, 0, 0, athens)},
+		{name: "month", period: &Period{Value: 1, PeriodType: Month}, invocationPoint: time.Date(2021, 8, 1, 0, 0, 0, 0, athens)},
+		{name: "day", period: &Period{Value: 1, PeriodType: Day}, invocationPoint: time.Date(2021, 7, 15, 0, 0, 0, 0, athens)},
+		{name: "hour", period: &Period{Value: 1, PeriodType: Hour}, invocationPoint: time.Date(2021, 7, 15, 0, 0, 0, 0, athens)},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			task, err := New(tc.period, athens, start)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, tc.invocationPoint.Equal(task.InvocationPoint), task.InvocationPoint)
+			assert.Equal(t, athens, task.InvocationPoint.Location())
+		})
+	}
+}
+
+func TestPeriodicTask_NextPrev(t *testing.T) {
+	athens, err := time.LoadLocation("Europe/Athens")
+	require.NoError(t, err)
+
+	point := time.Date(2021, 3, 1, 0, 0, 0, 0, athens)
+
+	tt := []struct {
+		name   string
+		period *Period
+		next   time.Time
+		prev   time.Time
+	}{
+		{name: "years", period: &Period{Value: 2, PeriodType: Year}, next: time.Date(2023, 3, 1, 0, 0, 0, 0, athens), prev: time.Date(2019, 3, 1, 0, 0, 0, 0, athens)},
+		{name: "months", period: &Period{Value: 1, PeriodType: Month}, next: time.Date(2021, 4, 1, 0, 0, 0, 0, athens), prev: time.Date(2021, 2, 1, 0, 0, 0, 0, athens)},
+		{name: "days across dst", period: &Period{Value: 28, PeriodType: Day}, next: time.Date(2021, 3, 29, 0, 0, 0, 0, athens), prev: time.Date(2021, 2, 1, 0, 0, 0, 0, athens)},
+		{name: "hours", period: &Period{Value: 5, PeriodType: Hour}, next: time.Date(2021, 3, 1, 5, 0, 0, 0, athens), prev: time.Date(2021, 2, 28, 19, 0, 0, 0, athens)},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			task := &PeriodicTask{Period: tc.period, InvocationPoint: point, Timezone: athens}
+
+			next, err := task.Next(point)
+			require.NoError(t, err)
+			assert.True(t, tc.next.Equal(next), next)
+
+			prev, err := task.Prev(point)
+			require.NoError(t, err)
+			assert.True(t, tc.prev.Equal(prev), prev)
+
+			back, err := task.Prev(next)
+			require.NoError(t, err)
+			assert.True(t, point.Equal(back), back)
+		})
+	}
+}
+
+func TestPeriodicTask_All(t *testing.T) {
+	t1 := time.Date(2021, 7, 28, 20, 46, 3, 0, time.UTC)
+	t2 := time.Date(2021, 8, 2, 12, 34, 56, 0, time.UTC)
+
+	athens, err := time.LoadLocation("Europe/Athens")
+	require.NoError(t, err)
+
+	task, err := New(&Period{Value: 2, PeriodType: Day}, athens, t1)
+	require.NoError(t, err)
+
+	points, err := task.All(t1, t2)
+	require.NoError(t, err)
+
+	expected := []string{"2021-07-28T21:00:00Z", "2021-07-30T21:00:00Z", "2021-08-01T21:00:00Z"}
+	require.Len(t, points, len(expected))
+
+	for i, point := range points {
+		assert.Equal(t, expected[i], point.UTC().Format(time.RFC3339))
+	}
+
+	// points before t1 are skipped but keep the alignment of the task.
+	points, err = task.All(time.Date(2021, 7, 30, 0, 0, 0, 0, time.UTC), t2)
+	require.NoError(t, err)
+	assert.Len(t, points, 2)
+
+	points, err = task.All(t2, t1)
+	require.NoError(t, err)
+	assert.Empty(t, points)
+}
+
+func TestPeriodicTask_Each(t *testing.T) {
+	t1 := time.Date(2021, 7, 28, 20, 46, 3, 0, time.UTC)
+
+	task, err := New(&Period{Value: 1, PeriodType: Hour}, time.UTC, t1)
+	require.NoError(t, err)
+
+	// the window is unbounded, Each stops as soon as fn does.
+	var points []time.Time
+
+	err = task.Each(t1, time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC), func(point time.Time) bool {
+		points = append(points, point)
+		return len(points) < 3
+	})
+	require.NoError(t, err)
+
+	require.Len(t, points, 3)
+	assert.Equal(t, time.Date(2021, 7, 28, 23, 0, 0, 0, time.UTC), points[2])
+}
+
+func TestPeriodicTask_Overflow(t *testing.T) {
+	t1 := time.Date(2021, 7, 14, 20, 46, 3, 0, time.UTC)
+	t2 := time.Date(2321, 7, 14, 20, 46, 3, 0, time.UTC)
+
+	for _, period := range []string{"2562048h", "102482d", "3444mo", "292y", "9223372036854775807h"} {
+		t.Run(period, func(t *testing.T) {
+			_, err := ParsePeriod(period)
+			assert.ErrorIs(t, err, ErrInvalidPeriod)
+		})
+	}
+
+	// the longest periods step forward instead of wrapping around.
+	for _, period := range []string{"2562047h", "102481d", "3443mo", "291y"} {
+		t.Run(period, func(t *testing.T) {
+			p, err := ParsePeriod(period)
+			require.NoError(t, err)
+
+			task, err := New(p, time.UTC, t1)
+			require.NoError(t, err)
+
+			next, err := task.Next(task.InvocationPoint)
+			require.NoError(t, err)
+			assert.True(t, next.After(task.InvocationPoint), next)
+
+			points, err := task.All(t1, t2)
+			require.NoError(t, err)
+			assert.Len(t, points, 2)
+		})
+	}
+}